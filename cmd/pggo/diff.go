@@ -0,0 +1,65 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/jackc/pgx/v4"
+
+	"github.com/bongnv/pggo/internal/diff"
+	"github.com/bongnv/pggo/internal/generator"
+	"github.com/bongnv/pggo/internal/loader"
+)
+
+type diffCmd struct {
+	From     string `kong:"required,name='from',help='Connection string or snapshot file of the current schema'"`
+	To       string `kong:"required,name='to',help='Connection string or snapshot file of the desired schema'"`
+	ExitCode bool   `kong:"optional,name='exit-code',help='Exit with an error if the schemas are different'"`
+}
+
+func (c *diffCmd) Run(g *globals) error {
+	from, err := g.newSourceLoader(c.From).Load()
+	if err != nil {
+		return err
+	}
+
+	to, err := g.newSourceLoader(c.To).Load()
+	if err != nil {
+		return err
+	}
+
+	result := diff.Compare(from, to)
+	if result.Empty() {
+		fmt.Println("No changes.")
+		return nil
+	}
+
+	fmt.Printf("Changes:\n%s\nMigration:\n%s", result.Report(), result.SQL())
+	if c.ExitCode {
+		return errors.New("schemas are different")
+	}
+
+	return nil
+}
+
+// newSourceLoader returns a loader for a connection string or a snapshot file.
+// Existing files are snapshots, otherwise the source is a connection string if pgx can parse it,
+// e.g. a URL or a key/value DSN like "host=localhost dbname=app".
+func (g *globals) newSourceLoader(source string) generator.SchemaLoader {
+	if info, err := os.Stat(source); err == nil && !info.IsDir() {
+		return loader.SnapshotLoader{
+			FileName: source,
+		}
+	}
+
+	if _, err := pgx.ParseConfig(source); err == nil {
+		l := g.newLoader(nil)
+		l.URL = source
+		return l
+	}
+
+	return loader.SnapshotLoader{
+		FileName: source,
+	}
+}
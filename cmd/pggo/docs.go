@@ -0,0 +1,20 @@
+package main
+
+import (
+	"github.com/bongnv/pggo/internal/generator"
+)
+
+type docsCmd struct {
+	Out string `kong:"optional,name='out',default='docs',help='Directory for documentation files, --dir is ignored'"`
+}
+
+func (c *docsCmd) Run(g *globals) error {
+	writer := g.newWriterIn(c.Out)
+
+	gen := generator.DocsGenerator{
+		SchemaLoader: g.newLoader(nil),
+		Writer:       writer,
+	}
+
+	return gen.Generate()
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"time"
+
+	"github.com/bongnv/pggo/internal/generator"
+)
+
+type generateCmd struct {
+	Tables       []string `kong:"optional,name='table',short='t',help='Names of tables for generating code'"`
+	Queries      []string `kong:"optional,name='queries',short='q',help='SQL files containing annotated queries for generating code'"`
+	Repositories bool     `kong:"optional,name='repositories',help='Generate repository interfaces, implementations and in-memory fakes'"`
+	Factories    bool     `kong:"optional,name='factories',help='Generate factories building and inserting test data'"`
+	Proto        bool     `kong:"optional,name='proto',help='Generate .proto files and converters configured by proto in the config file'"`
+	All          bool     `kong:"optional,name='all',short='a',help='Generate code for all tables in the schema'"`
+	Workers      int      `kong:"optional,name='workers',help='Number of tables rendered concurrently, defaults to the number of CPUs'"`
+	Cache        string   `kong:"optional,name='cache',help='JSON file caching hashes of generated tables to skip unchanged ones'"`
+
+	Watch          bool          `kong:"optional,name='watch',short='w',help='Keep regenerating code for tables that changed'"`
+	Interval       time.Duration `kong:"optional,name='interval',default='2s',help='Interval between two checks in watch mode'"`
+	Migrations     string        `kong:"optional,name='migrations',type='existingdir',help='Directory of migration files to watch instead of polling the DB schema, it requires --migrate-command'"`
+	MigrateCommand string        `kong:"optional,name='migrate-command',help='Shell command to apply migrations after the migration directory changes'"`
+}
+
+func (c *generateCmd) Run(g *globals) error {
+	if c.Watch && (g.DryRun || g.Stdout) {
+		return errors.New("--watch can't be used with --dry-run or --stdout")
+	}
+
+	cfg, err := g.loadConfig()
+	if err != nil {
+		return err
+	}
+
+	writer := g.newWriter()
+
+	gen := &generator.Generator{
+		SchemaLoader: g.newLoader(c.Queries),
+		Tables:       c.Tables,
+		All:          c.All,
+		Writer:       writer,
+		Repositories: c.Repositories,
+		Factories:    c.Factories,
+		Tags:         cfg.Tags,
+		Overrides:    cfg.Overrides,
+		Workers:      c.Workers,
+	}
+
+	if c.Proto {
+		gen.Proto = cfg.Proto
+		if gen.Proto == nil {
+			gen.Proto = &generator.ProtoOptions{}
+		}
+	}
+
+	if c.Cache == "" {
+		if err := c.generate(gen); err != nil {
+			return err
+		}
+
+		return g.reportStale(writer)
+	}
+
+	cache, err := g.loadCache(c.Cache)
+	if err != nil {
+		return err
+	}
+
+	gen.Cache = cache
+	gen.OutputDir, err = filepath.Abs(g.Dir)
+	if err != nil {
+		return err
+	}
+
+	if err := c.generate(gen); err != nil {
+		return err
+	}
+
+	return cache.Save()
+}
+
+// generate generates code once, or keeps regenerating in watch mode until it's interrupted.
+func (c *generateCmd) generate(gen *generator.Generator) error {
+	if !c.Watch {
+		return gen.Generate()
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	watcher := &generator.Watcher{
+		Generator:      gen,
+		Interval:       c.Interval,
+		MigrationDir:   c.Migrations,
+		MigrateCommand: c.MigrateCommand,
+		Out:            os.Stderr,
+	}
+
+	return watcher.Watch(ctx)
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/bongnv/pggo/internal/lint"
+)
+
+type lintCmd struct {
+	Format   string `kong:"optional,name='format',short='f',default='text',enum='text,json,sarif',help='Output format: text, json or sarif'"`
+	Artifact string `kong:"optional,name='artifact',help='File which SARIF results are located in, it is the config file by default'"`
+}
+
+func (c *lintCmd) Run(g *globals) error {
+	cfg, err := g.loadConfig()
+	if err != nil {
+		return err
+	}
+
+	linter := lint.Linter{
+		SchemaLoader: g.newLoader(nil),
+		Rules:        cfg.Lint.Rules,
+	}
+
+	issues, err := linter.Lint()
+	if err != nil {
+		return err
+	}
+
+	artifact := c.Artifact
+	if artifact == "" {
+		artifact = g.Config
+	}
+
+	if artifact == "" {
+		// the working directory is reported if there is no file to locate issues
+		artifact = "."
+	}
+
+	if err := lint.Write(os.Stdout, c.Format, artifact, issues); err != nil {
+		return err
+	}
+
+	if len(issues) > 0 {
+		return fmt.Errorf("found %d issues", len(issues))
+	}
+
+	return nil
+}
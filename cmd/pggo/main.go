@@ -1,39 +1,104 @@
 package main
 
 import (
+	"errors"
+	"os"
+
 	"github.com/alecthomas/kong"
 
+	"github.com/bongnv/pggo/internal/config"
 	"github.com/bongnv/pggo/internal/generator"
 	"github.com/bongnv/pggo/internal/loader"
 	"github.com/bongnv/pggo/internal/writer"
 )
 
+// globals contains flags shared by all commands.
+type globals struct {
+	Config     string `kong:"optional,name='config',short='c',type='existingfile',help='Path to the YAML configuration file'"`
+	Dir        string `kong:"optional,name='dir',short='d',default='.',help='Directory for output files'"`
+	URL        string `kong:"optional,name='url',short='u',help='Connection URL to PostgreSQL server, PG* environment variables are used if it is empty'"`
+	Partitions bool   `kong:"optional,name='partitions',help='Include partitions and inheritance children of tables in the schema'"`
+	DryRun     bool   `kong:"optional,name='dry-run',xor='output',help='List files that would be created or modified without writing them'"`
+	Stdout     bool   `kong:"optional,name='stdout',xor='output',help='Write all files to stdout as a txtar archive'"`
+}
+
 var cli struct {
-	Dir   string `kong:"optional,name='dir',short='d',default='.',help='Directory for output files'"`
-	URL   string `kong:"required,name='url',short='u',help='Connection URL to PostgreSQL server'"`
-	Table string `kong:"optional,name='table',short='t',help='Name of the table for generating code'"`
+	globals
+
+	Generate   generateCmd   `kong:"cmd,default='withargs',help='Generate Go code from the DB schema, it is the default command'"`
+	Docs       docsCmd       `kong:"cmd,help='Generate Markdown documentation and ER diagrams from the DB schema'"`
+	Plugin     pluginCmd     `kong:"cmd,help='Run an external plugin to generate files from the DB schema'"`
+	Snapshot   snapshotCmd   `kong:"cmd,help='Save the DB schema into a JSON snapshot file'"`
+	Diff       diffCmd       `kong:"cmd,help='Compare two schemas and print changes with the migration script'"`
+	Lint       lintCmd       `kong:"cmd,help='Check the DB schema against lint rules'"`
+	TypeScript typeScriptCmd `kong:"cmd,name='typescript',help='Generate TypeScript definitions of tables and enums'"`
 }
 
 func main() {
 	ctx := kong.Parse(
 		&cli,
-		kong.Name("gogen"),
+		kong.Name("pggo"),
 		kong.Description("A code generation tool using Go template"),
+		kong.Bind(&cli.globals),
 	)
 
-	loader := loader.PostgreSQLLoader{
-		URL: cli.URL,
+	ctx.FatalIfErrorf(ctx.Run())
+}
+
+func (g *globals) loadConfig() (*config.Config, error) {
+	if g.Config == "" {
+		return &config.Config{}, nil
+	}
+
+	return config.Load(g.Config)
+}
+
+func (g *globals) newLoader(queryFiles []string) loader.PostgreSQLLoader {
+	return loader.PostgreSQLLoader{
+		URL:        g.URL,
+		QueryFiles: queryFiles,
+		Partitions: g.Partitions,
 	}
+}
 
-	writer := writer.FileWriter{
-		Dir: cli.Dir,
+func (g *globals) newWriter() generator.Writer {
+	return g.newWriterIn(g.Dir)
+}
+
+// newWriterIn creates a writer of output files in dir, which is used by commands with their own output directory.
+func (g *globals) newWriterIn(dir string) generator.Writer {
+	switch {
+	case g.DryRun:
+		return &writer.DryRunWriter{
+			Dir: dir,
+			Out: os.Stdout,
+		}
+	case g.Stdout:
+		return writer.TxtarWriter{
+			Out: os.Stdout,
+		}
+	default:
+		return writer.FileWriter{
+			Dir: dir,
+		}
 	}
+}
+
+// reportStale reports generated files which would be left in Dir if w is a dry run writer.
+func (g *globals) reportStale(w generator.Writer) error {
+	if dryRun, ok := w.(*writer.DryRunWriter); ok {
+		return dryRun.ReportStale()
+	}
+
+	return nil
+}
 
-	gen := generator.Generator{
-		SchemaLoader: loader,
-		Table:        cli.Table,
-		Writer:       writer,
+// loadCache loads the cache of generated tables. The cache only works with files written into Dir,
+// otherwise skipped tables would be missing from the output.
+func (g *globals) loadCache(fileName string) (*writer.FileCache, error) {
+	if g.DryRun || g.Stdout {
+		return nil, errors.New("--cache can't be used with --dry-run or --stdout")
 	}
 
-	ctx.FatalIfErrorf(gen.Generate())
+	return writer.LoadFileCache(fileName)
 }
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/bongnv/pggo/internal/generator"
+)
+
+type pluginCmd struct {
+	Options map[string]string `kong:"optional,name='opt',short='o',help='Options passed to the plugin in addition to ones from the configuration file, e.g. --opt package=graphql'"`
+	Command []string          `kong:"arg,passthrough,help='Plugin executable followed by its arguments'"`
+}
+
+func (c *pluginCmd) Run(g *globals) error {
+	cfg, err := g.loadConfig()
+	if err != nil {
+		return err
+	}
+
+	options := map[string]string{}
+	for k, v := range cfg.Plugins[filepath.Base(c.Command[0])] {
+		options[k] = v
+	}
+	for k, v := range c.Options {
+		options[k] = v
+	}
+
+	writer := g.newWriter()
+
+	gen := generator.PluginGenerator{
+		SchemaLoader: g.newLoader(nil),
+		Writer:       writer,
+		Command:      c.Command[0],
+		Args:         c.Command[1:],
+		Options:      options,
+		Stderr:       os.Stderr,
+	}
+
+	return gen.Generate()
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"github.com/bongnv/pggo/internal/generator"
+)
+
+type snapshotCmd struct {
+	File string `kong:"optional,name='file',short='f',default='schema.json',help='Name of the snapshot file'"`
+}
+
+func (c *snapshotCmd) Run(g *globals) error {
+	writer := g.newWriter()
+
+	gen := generator.SnapshotGenerator{
+		SchemaLoader: g.newLoader(nil),
+		Writer:       writer,
+		FileName:     c.File,
+	}
+
+	return gen.Generate()
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"github.com/bongnv/pggo/internal/generator"
+)
+
+type typeScriptCmd struct {
+	File string `kong:"optional,name='file',short='f',default='schema.d.ts',help='Name of the TypeScript definition file'"`
+}
+
+func (c *typeScriptCmd) Run(g *globals) error {
+	cfg, err := g.loadConfig()
+	if err != nil {
+		return err
+	}
+
+	writer := g.newWriter()
+
+	gen := generator.TypeScriptGenerator{
+		SchemaLoader: g.newLoader(nil),
+		Writer:       writer,
+		FileName:     c.File,
+		Tags:         cfg.Tags,
+		Overrides:    cfg.Overrides,
+	}
+
+	return gen.Generate()
+}
@@ -0,0 +1,47 @@
+package config
+
+import (
+	"bytes"
+	"os"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/bongnv/pggo/internal/generator"
+)
+
+// Config represents the configuration file of pggo.
+type Config struct {
+	// Tags defines struct tags generated for fields of models.
+	Tags []generator.Tag `yaml:"tags"`
+	// Overrides customises code generated for columns, keyed by "table.column".
+	Overrides map[string]generator.ColumnOverride `yaml:"overrides"`
+	// Proto configures .proto files and converters generated with --proto.
+	Proto *generator.ProtoOptions `yaml:"proto"`
+	// Plugins contains options passed to plugins, keyed by names of plugin executables.
+	Plugins map[string]map[string]string `yaml:"plugins"`
+	// Lint configures the lint command.
+	Lint Lint `yaml:"lint"`
+}
+
+// Lint configures rules of the lint command.
+type Lint struct {
+	// Rules enables or disables rules by names, rules are enabled if they aren't listed.
+	Rules map[string]bool `yaml:"rules"`
+}
+
+// Load reads the configuration from a YAML file. Unknown fields are rejected.
+func Load(fileName string) (*Config, error) {
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{}
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(cfg); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
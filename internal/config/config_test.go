@@ -0,0 +1,77 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bongnv/pggo/internal/config"
+	"github.com/bongnv/pggo/internal/generator"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	fileName := filepath.Join(t.TempDir(), "pggo.yaml")
+	require.NoError(t, os.WriteFile(fileName, []byte(content), 0644))
+	return fileName
+}
+
+func Test_Load(t *testing.T) {
+	fileName := writeConfig(t, `
+tags:
+  - key: json
+    style: camel
+    omitempty: true
+  - key: db
+overrides:
+  users.password_hash:
+    tags:
+      json: "-"
+proto:
+  package: app.v1
+  go_package: example.com/app/pb
+plugins:
+  pggo-graphql:
+    package: gql
+lint:
+  rules:
+    nullable-boolean: false
+`)
+
+	cfg, err := config.Load(fileName)
+	require.NoError(t, err)
+	require.Equal(t, &config.Config{
+		Tags: []generator.Tag{
+			{Key: "json", Style: generator.StyleCamel, OmitEmpty: true},
+			{Key: "db"},
+		},
+		Overrides: map[string]generator.ColumnOverride{
+			"users.password_hash": {
+				Tags: map[string]string{"json": "-"},
+			},
+		},
+		Proto: &generator.ProtoOptions{
+			Package:   "app.v1",
+			GoPackage: "example.com/app/pb",
+		},
+		Plugins: map[string]map[string]string{
+			"pggo-graphql": {"package": "gql"},
+		},
+		Lint: config.Lint{
+			Rules: map[string]bool{"nullable-boolean": false},
+		},
+	}, cfg)
+}
+
+func Test_Load_unknown_field(t *testing.T) {
+	fileName := writeConfig(t, "tag:\n  - key: json\n")
+
+	_, err := config.Load(fileName)
+	require.EqualError(t, err, "yaml: unmarshal errors:\n  line 1: field tag not found in type config.Config")
+}
+
+func Test_Load_missing_file(t *testing.T) {
+	_, err := config.Load(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,731 @@
+package diff
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/bongnv/pggo/internal/generator"
+)
+
+// Result contains differences between two schemas.
+type Result struct {
+	// Changes contains human readable descriptions of changes,
+	// prefixed by "+" for added, "-" for dropped and "~" for altered objects.
+	Changes []string
+	// Statements contains DDL statements migrating the source schema to the target schema.
+	Statements []string
+}
+
+// Empty returns true if there is no difference between the two schemas.
+func (r *Result) Empty() bool {
+	return len(r.Changes) == 0
+}
+
+// Report returns the human readable change report.
+func (r *Result) Report() string {
+	return joinLines(r.Changes)
+}
+
+// SQL returns the migration script.
+func (r *Result) SQL() string {
+	return joinLines(r.Statements)
+}
+
+// Phases order DDL statements, so that objects are dropped before objects they depend on
+// and created after objects they depend on.
+const (
+	phaseCreateTypes = iota
+	phaseDropForeignKeys
+	phaseDropIndexes
+	phaseDropConstraints
+	phaseDropTables
+	phaseCreateTables
+	phaseCreatePartitions
+	phaseAlterColumns
+	phaseAddConstraints
+	phaseCreateIndexes
+	phaseAddForeignKeys
+	phaseDropTypes
+	numPhases
+)
+
+type differ struct {
+	changes []string
+	phases  [numPhases][]string
+}
+
+// Compare compares two schemas and returns changes to migrate the from schema to the to schema.
+// Partitions and inheritance children are only compared as a whole since changes of their parents are propagated.
+func Compare(from, to *generator.Schema) *Result {
+	d := &differ{}
+	d.compareEnums(from.Enums, to.Enums)
+	d.compareTables(from.Tables, to.Tables)
+
+	result := &Result{
+		Changes: d.changes,
+	}
+
+	for _, statements := range d.phases {
+		result.Statements = append(result.Statements, statements...)
+	}
+
+	return result
+}
+
+func (d *differ) change(format string, args ...interface{}) {
+	d.changes = append(d.changes, fmt.Sprintf(format, args...))
+}
+
+func (d *differ) exec(phase int, format string, args ...interface{}) {
+	d.phases[phase] = append(d.phases[phase], fmt.Sprintf(format, args...))
+}
+
+// unsupported reports a change which can't be migrated by a DDL statement, it's left as a comment in the script.
+func (d *differ) unsupported(phase int, format string, args ...interface{}) {
+	d.change(format, args...)
+	d.exec(phase, "-- unsupported: "+format, args...)
+}
+
+func (d *differ) compareEnums(from, to map[string]*generator.Enum) {
+	for _, name := range unionKeys(enumNames(from), enumNames(to)) {
+		oldEnum, newEnum := from[name], to[name]
+		switch {
+		case oldEnum == nil:
+			d.change("+ enum %s (%s)", name, strings.Join(newEnum.Values, ", "))
+			d.exec(phaseCreateTypes, "CREATE TYPE %s AS ENUM (%s);", quoteIdent(name), quoteLiterals(newEnum.Values))
+		case newEnum == nil:
+			d.change("- enum %s", name)
+			d.exec(phaseDropTypes, "DROP TYPE %s;", quoteIdent(name))
+		default:
+			d.compareEnumValues(oldEnum, newEnum)
+		}
+	}
+}
+
+func (d *differ) compareEnumValues(from, to *generator.Enum) {
+	for _, v := range from.Values {
+		if !containsString(to.Values, v) {
+			d.unsupported(phaseCreateTypes, "- enum value %s.%s", from.Name, v)
+		}
+	}
+
+	// new values are placed after the previous value, leading values are placed before the first existing value
+	var first string
+	for _, v := range to.Values {
+		if containsString(from.Values, v) {
+			first = v
+			break
+		}
+	}
+
+	for i, v := range to.Values {
+		if containsString(from.Values, v) {
+			continue
+		}
+
+		d.change("+ enum value %s.%s", to.Name, v)
+		switch {
+		case i > 0:
+			d.exec(phaseCreateTypes, "ALTER TYPE %s ADD VALUE %s AFTER %s;", quoteIdent(to.Name), quoteLiteral(v), quoteLiteral(to.Values[i-1]))
+		case first != "":
+			d.exec(phaseCreateTypes, "ALTER TYPE %s ADD VALUE %s BEFORE %s;", quoteIdent(to.Name), quoteLiteral(v), quoteLiteral(first))
+		default:
+			d.exec(phaseCreateTypes, "ALTER TYPE %s ADD VALUE %s;", quoteIdent(to.Name), quoteLiteral(v))
+		}
+	}
+}
+
+func (d *differ) compareTables(from, to map[string]*generator.Table) {
+	for _, name := range unionKeys(tableNames(from), tableNames(to)) {
+		oldTable, newTable := from[name], to[name]
+		switch {
+		case oldTable == nil:
+			d.createTable(newTable)
+		case newTable == nil:
+			d.change("- table %s", name)
+			// partitions and children are dropped together with their parents
+			if parent := oldTable.Parent; parent == "" || to[parent] != nil {
+				d.exec(phaseDropTables, "DROP TABLE %s;", quoteIdent(name))
+			}
+		default:
+			d.alterTable(oldTable, newTable)
+		}
+	}
+}
+
+func (d *differ) createTable(t *generator.Table) {
+	d.change("+ table %s", t.Name)
+	if t.Parent != "" && t.PartitionBound != "" {
+		d.exec(phaseCreatePartitions, "CREATE TABLE %s PARTITION OF %s %s;", quoteIdent(t.Name), quoteIdent(t.Parent), t.PartitionBound)
+		return
+	}
+
+	var defs []string
+	for _, c := range columnsByPosition(t) {
+		defs = append(defs, columnDefinition(t, c))
+		d.checkSequence(t, c)
+	}
+
+	if t.PrimaryKey != nil {
+		defs = append(defs, "CONSTRAINT "+primaryKeyDefinition(t.PrimaryKey))
+	}
+
+	for _, uk := range t.UniqueKeys {
+		defs = append(defs, "CONSTRAINT "+uniqueKeyDefinition(uk))
+	}
+
+	for _, check := range t.Checks {
+		defs = append(defs, "CONSTRAINT "+checkDefinition(check))
+	}
+
+	stmt := fmt.Sprintf("CREATE TABLE %s (\n  %s\n)", quoteIdent(t.Name), strings.Join(defs, ",\n  "))
+	if t.PartitionKey != nil {
+		stmt += " PARTITION BY " + t.PartitionKey.Definition
+	}
+
+	if t.Parent != "" {
+		stmt += " INHERITS (" + quoteIdent(t.Parent) + ")"
+	}
+
+	d.exec(phaseCreateTables, "%s;", stmt)
+
+	for _, index := range standaloneIndexes(t) {
+		d.exec(phaseCreateIndexes, "%s;", index.Definition)
+	}
+
+	for _, fk := range t.ForeignKeys {
+		d.exec(phaseAddForeignKeys, "ALTER TABLE %s ADD CONSTRAINT %s;", quoteIdent(t.Name), foreignKeyDefinition(fk))
+	}
+}
+
+func (d *differ) alterTable(from, to *generator.Table) {
+	if from.Parent != to.Parent || from.PartitionBound != to.PartitionBound {
+		d.unsupported(phaseAlterColumns, "~ table %s: partition of %s %s -> %s %s", to.Name, from.Parent, from.PartitionBound, to.Parent, to.PartitionBound)
+	}
+
+	if partitionKeyDefinition(from) != partitionKeyDefinition(to) {
+		d.unsupported(phaseAlterColumns, "~ table %s: partition by %s -> %s", to.Name, partitionKeyDefinition(from), partitionKeyDefinition(to))
+	}
+
+	if to.Parent != "" {
+		return
+	}
+
+	d.compareColumns(from, to)
+	d.comparePrimaryKeys(from, to)
+	d.compareUniqueKeys(from, to)
+	d.compareChecks(from, to)
+	d.compareForeignKeys(from, to)
+	d.compareIndexes(from, to)
+}
+
+func (d *differ) compareColumns(from, to *generator.Table) {
+	table := quoteIdent(to.Name)
+	for _, c := range from.Columns {
+		if to.Column(c.Name) == nil {
+			d.change("- column %s.%s", to.Name, c.Name)
+			d.exec(phaseAlterColumns, "ALTER TABLE %s DROP COLUMN %s;", table, quoteIdent(c.Name))
+		}
+	}
+
+	for _, newCol := range columnsByPosition(to) {
+		oldCol := from.Column(newCol.Name)
+		if oldCol == nil {
+			d.change("+ column %s.%s %s", to.Name, newCol.Name, columnType(newCol))
+			d.exec(phaseAlterColumns, "ALTER TABLE %s ADD COLUMN %s;", table, columnDefinition(to, newCol))
+			d.checkSequence(to, newCol)
+			continue
+		}
+
+		col := quoteIdent(newCol.Name)
+		if oldType, newType := columnType(oldCol), columnType(newCol); oldType != newType {
+			d.change("~ column %s.%s: type %s -> %s", to.Name, newCol.Name, oldType, newType)
+			d.exec(phaseAlterColumns, "ALTER TABLE %s ALTER COLUMN %s TYPE %s USING %s::%s;", table, col, newType, col, newType)
+		}
+
+		if oldCol.Nullable != newCol.Nullable {
+			d.change("~ column %s.%s: nullable %s -> %s", to.Name, newCol.Name, yesNo(oldCol.Nullable), yesNo(newCol.Nullable))
+			if newCol.Nullable {
+				d.exec(phaseAlterColumns, "ALTER TABLE %s ALTER COLUMN %s DROP NOT NULL;", table, col)
+			} else {
+				d.exec(phaseAlterColumns, "ALTER TABLE %s ALTER COLUMN %s SET NOT NULL;", table, col)
+			}
+		}
+
+		// identity is dropped before setting a default and added after dropping the default,
+		// as a column can't have both
+		if oldCol.Identity != "" && newCol.Identity == "" {
+			d.change("~ column %s.%s: identity %s -> (none)", to.Name, newCol.Name, oldCol.Identity)
+			d.exec(phaseAlterColumns, "ALTER TABLE %s ALTER COLUMN %s DROP IDENTITY;", table, col)
+		}
+
+		if oldCol.Default != newCol.Default {
+			d.change("~ column %s.%s: default %s -> %s", to.Name, newCol.Name, noneIfEmpty(oldCol.Default), noneIfEmpty(newCol.Default))
+			switch {
+			case newCol.Default == "":
+				d.exec(phaseAlterColumns, "ALTER TABLE %s ALTER COLUMN %s DROP DEFAULT;", table, col)
+			case isSerial(to, newCol):
+				// the sequence is created the same way as serial columns create it
+				d.exec(phaseAlterColumns, "CREATE SEQUENCE IF NOT EXISTS %s AS %s OWNED BY %s.%s;", sequenceOf(newCol), newCol.DataType, table, col)
+				d.exec(phaseAlterColumns, "ALTER TABLE %s ALTER COLUMN %s SET DEFAULT %s;", table, col, newCol.Default)
+			default:
+				d.checkSequence(to, newCol)
+				d.exec(phaseAlterColumns, "ALTER TABLE %s ALTER COLUMN %s SET DEFAULT %s;", table, col, newCol.Default)
+			}
+		}
+
+		switch {
+		case newCol.Identity == "" || oldCol.Identity == newCol.Identity:
+		case oldCol.Identity == "":
+			d.change("~ column %s.%s: identity (none) -> %s", to.Name, newCol.Name, newCol.Identity)
+			d.exec(phaseAlterColumns, "ALTER TABLE %s ALTER COLUMN %s ADD GENERATED %s AS IDENTITY;", table, col, newCol.Identity)
+		default:
+			d.change("~ column %s.%s: identity %s -> %s", to.Name, newCol.Name, oldCol.Identity, newCol.Identity)
+			d.exec(phaseAlterColumns, "ALTER TABLE %s ALTER COLUMN %s SET GENERATED %s;", table, col, newCol.Identity)
+		}
+	}
+}
+
+// checkSequence leaves a comment if the default of a column uses a sequence which isn't created by a serial type,
+// as sequences aren't compared.
+func (d *differ) checkSequence(t *generator.Table, c *generator.Column) {
+	if seq := sequenceOf(c); seq != "" && !isSerial(t, c) {
+		d.exec(phaseCreateTypes, "-- unsupported: sequence %s of column %s.%s must exist", seq, t.Name, c.Name)
+	}
+}
+
+func (d *differ) comparePrimaryKeys(from, to *generator.Table) {
+	oldKey, newKey := from.PrimaryKey, to.PrimaryKey
+	if constraintDefinition(oldKey) == constraintDefinition(newKey) {
+		return
+	}
+
+	if oldKey != nil {
+		d.dropConstraint(to.Name, "primary key", oldKey.Name, newKey != nil && newKey.Name == oldKey.Name)
+	}
+
+	if newKey != nil {
+		d.addConstraint(to.Name, "primary key", newKey, primaryKeyDefinition(newKey), oldKey)
+	}
+}
+
+func (d *differ) compareUniqueKeys(from, to *generator.Table) {
+	oldKeys, newKeys := constraintsByName(from.UniqueKeys), constraintsByName(to.UniqueKeys)
+	for _, name := range unionKeys(constraintNames(oldKeys), constraintNames(newKeys)) {
+		oldKey, newKey := oldKeys[name], newKeys[name]
+		if constraintDefinition(oldKey) == constraintDefinition(newKey) {
+			continue
+		}
+
+		if oldKey != nil {
+			d.dropConstraint(to.Name, "unique key", name, newKey != nil)
+		}
+
+		if newKey != nil {
+			d.addConstraint(to.Name, "unique key", newKey, uniqueKeyDefinition(newKey), oldKey)
+		}
+	}
+}
+
+// dropConstraint drops a constraint, the change is reported by addConstraint if the constraint is recreated.
+func (d *differ) dropConstraint(table, kind, name string, recreated bool) {
+	if !recreated {
+		d.change("- %s %s.%s", kind, table, name)
+	}
+
+	d.exec(phaseDropConstraints, "ALTER TABLE %s DROP CONSTRAINT %s;", quoteIdent(table), quoteIdent(name))
+}
+
+func (d *differ) addConstraint(table, kind string, c *generator.Constraint, definition string, old *generator.Constraint) {
+	if old != nil && old.Name == c.Name {
+		d.change("~ %s %s.%s: (%s) -> (%s)", kind, table, c.Name, strings.Join(old.Columns, ", "), strings.Join(c.Columns, ", "))
+	} else {
+		d.change("+ %s %s.%s (%s)", kind, table, c.Name, strings.Join(c.Columns, ", "))
+	}
+
+	d.exec(phaseAddConstraints, "ALTER TABLE %s ADD CONSTRAINT %s;", quoteIdent(table), definition)
+}
+
+func (d *differ) compareChecks(from, to *generator.Table) {
+	oldChecks, newChecks := checksByName(from.Checks), checksByName(to.Checks)
+	table := quoteIdent(to.Name)
+	for _, name := range unionKeys(checkNames(oldChecks), checkNames(newChecks)) {
+		oldCheck, newCheck := oldChecks[name], newChecks[name]
+		switch {
+		case oldCheck == nil:
+			d.change("+ check %s.%s %s", to.Name, name, newCheck.Definition)
+		case newCheck == nil:
+			d.change("- check %s.%s", to.Name, name)
+		case oldCheck.Definition != newCheck.Definition:
+			d.change("~ check %s.%s: %s -> %s", to.Name, name, oldCheck.Definition, newCheck.Definition)
+		default:
+			continue
+		}
+
+		if oldCheck != nil {
+			d.exec(phaseDropConstraints, "ALTER TABLE %s DROP CONSTRAINT %s;", table, quoteIdent(name))
+		}
+
+		if newCheck != nil {
+			d.exec(phaseAddConstraints, "ALTER TABLE %s ADD CONSTRAINT %s;", table, checkDefinition(newCheck))
+		}
+	}
+}
+
+func (d *differ) compareForeignKeys(from, to *generator.Table) {
+	oldKeys, newKeys := foreignKeysByName(from.ForeignKeys), foreignKeysByName(to.ForeignKeys)
+	table := quoteIdent(to.Name)
+	for _, name := range unionKeys(foreignKeyNames(oldKeys), foreignKeyNames(newKeys)) {
+		oldKey, newKey := oldKeys[name], newKeys[name]
+		switch {
+		case oldKey == nil:
+			d.change("+ foreign key %s.%s", to.Name, name)
+		case newKey == nil:
+			d.change("- foreign key %s.%s", to.Name, name)
+		case foreignKeyDefinition(oldKey) != foreignKeyDefinition(newKey):
+			d.change("~ foreign key %s.%s", to.Name, name)
+		default:
+			continue
+		}
+
+		if oldKey != nil {
+			d.exec(phaseDropForeignKeys, "ALTER TABLE %s DROP CONSTRAINT %s;", table, quoteIdent(name))
+		}
+
+		if newKey != nil {
+			d.exec(phaseAddForeignKeys, "ALTER TABLE %s ADD CONSTRAINT %s;", table, foreignKeyDefinition(newKey))
+		}
+	}
+}
+
+func (d *differ) compareIndexes(from, to *generator.Table) {
+	oldIndexes, newIndexes := indexesByName(standaloneIndexes(from)), indexesByName(standaloneIndexes(to))
+	for _, name := range unionKeys(indexNames(oldIndexes), indexNames(newIndexes)) {
+		oldIndex, newIndex := oldIndexes[name], newIndexes[name]
+		switch {
+		case oldIndex == nil:
+			d.change("+ index %s.%s", to.Name, name)
+		case newIndex == nil:
+			d.change("- index %s.%s", to.Name, name)
+		case oldIndex.Definition != newIndex.Definition:
+			d.change("~ index %s.%s", to.Name, name)
+		default:
+			continue
+		}
+
+		if oldIndex != nil {
+			d.exec(phaseDropIndexes, "DROP INDEX %s;", quoteIdent(name))
+		}
+
+		if newIndex != nil {
+			d.exec(phaseCreateIndexes, "%s;", newIndex.Definition)
+		}
+	}
+}
+
+// standaloneIndexes returns indexes of a table which aren't created by its primary key or unique keys.
+func standaloneIndexes(t *generator.Table) []*generator.Index {
+	var indexes []*generator.Index
+	for _, index := range t.Indexes {
+		if index.Primary || constraintsByName(t.UniqueKeys)[index.Name] != nil {
+			continue
+		}
+
+		indexes = append(indexes, index)
+	}
+
+	return indexes
+}
+
+func columnType(c *generator.Column) string {
+	switch {
+	case c.MaxLength > 0:
+		return fmt.Sprintf("%s(%d)", c.DataType, c.MaxLength)
+	case c.NumericPrecision > 0:
+		return fmt.Sprintf("%s(%d,%d)", c.DataType, c.NumericPrecision, c.NumericScale)
+	default:
+		return c.DataType
+	}
+}
+
+// columnDefinition returns the definition of a column, serial columns use serial types to create their sequences.
+func columnDefinition(t *generator.Table, c *generator.Column) string {
+	dataType, defaultValue := columnType(c), c.Default
+	if isSerial(t, c) {
+		dataType, defaultValue = serialTypes[c.DataType], ""
+	}
+
+	def := quoteIdent(c.Name) + " " + dataType
+	if c.Identity != "" {
+		def += " GENERATED " + c.Identity + " AS IDENTITY"
+	}
+
+	if !c.Nullable {
+		def += " NOT NULL"
+	}
+
+	if defaultValue != "" {
+		def += " DEFAULT " + defaultValue
+	}
+
+	return def
+}
+
+// serialTypes maps integer types to serial types which create sequences of columns.
+var serialTypes = map[string]string{
+	"smallint": "smallserial",
+	"integer":  "serial",
+	"bigint":   "bigserial",
+}
+
+var nextvalDefault = regexp.MustCompile(`^nextval\('(.+)'::regclass\)$`)
+
+// sequenceOf returns the sequence used by the default of a column, it's empty if the default doesn't use nextval.
+func sequenceOf(c *generator.Column) string {
+	m := nextvalDefault.FindStringSubmatch(c.Default)
+	if m == nil {
+		return ""
+	}
+
+	return m[1]
+}
+
+// isSerial reports whether a column is a serial column, i.e. its default uses the sequence named by serial types.
+func isSerial(t *generator.Table, c *generator.Column) bool {
+	seq := sequenceOf(c)
+	return serialTypes[c.DataType] != "" && seq != "" && seq == quoteIdent(t.Name+"_"+c.Name+"_seq")
+}
+
+func constraintDefinition(c *generator.Constraint) string {
+	if c == nil {
+		return ""
+	}
+
+	return c.Name + " (" + quoteIdents(c.Columns) + ")"
+}
+
+func primaryKeyDefinition(c *generator.Constraint) string {
+	return fmt.Sprintf("%s PRIMARY KEY (%s)", quoteIdent(c.Name), quoteIdents(c.Columns))
+}
+
+func uniqueKeyDefinition(c *generator.Constraint) string {
+	return fmt.Sprintf("%s UNIQUE (%s)", quoteIdent(c.Name), quoteIdents(c.Columns))
+}
+
+func checkDefinition(c *generator.Check) string {
+	return quoteIdent(c.Name) + " " + c.Definition
+}
+
+func foreignKeyDefinition(fk *generator.ForeignKey) string {
+	def := fmt.Sprintf("%s FOREIGN KEY (%s) REFERENCES %s (%s)",
+		quoteIdent(fk.Name), quoteIdents(fk.Columns), quoteIdent(fk.RefTable), quoteIdents(fk.RefColumns))
+	if fk.OnDelete != "" {
+		def += " ON DELETE " + fk.OnDelete
+	}
+
+	if fk.OnUpdate != "" {
+		def += " ON UPDATE " + fk.OnUpdate
+	}
+
+	return def
+}
+
+func partitionKeyDefinition(t *generator.Table) string {
+	if t.PartitionKey == nil {
+		return ""
+	}
+
+	return t.PartitionKey.Definition
+}
+
+var simpleIdentifier = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+// reservedKeywords contains reserved keywords which are likely to be used as names.
+var reservedKeywords = map[string]bool{
+	"all": true, "check": true, "column": true, "constraint": true, "default": true, "desc": true,
+	"end": true, "from": true, "group": true, "limit": true, "offset": true, "order": true,
+	"primary": true, "references": true, "select": true, "table": true, "to": true, "user": true,
+	"where": true,
+}
+
+func quoteIdent(name string) string {
+	if simpleIdentifier.MatchString(name) && !reservedKeywords[name] {
+		return name
+	}
+
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func quoteIdents(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = quoteIdent(name)
+	}
+
+	return strings.Join(quoted, ", ")
+}
+
+func quoteLiteral(v string) string {
+	return "'" + strings.ReplaceAll(v, "'", "''") + "'"
+}
+
+func quoteLiterals(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = quoteLiteral(v)
+	}
+
+	return strings.Join(quoted, ", ")
+}
+
+func yesNo(v bool) string {
+	if v {
+		return "YES"
+	}
+
+	return "NO"
+}
+
+func noneIfEmpty(v string) string {
+	if v == "" {
+		return "(none)"
+	}
+
+	return v
+}
+
+func joinLines(lines []string) string {
+	if len(lines) == 0 {
+		return ""
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func containsString(values []string, v string) bool {
+	for _, s := range values {
+		if s == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+// unionKeys returns sorted unique names from both lists.
+func unionKeys(a, b []string) []string {
+	seen := map[string]bool{}
+	var names []string
+	for _, name := range append(a, b...) {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+func enumNames(m map[string]*generator.Enum) []string {
+	var names []string
+	for name := range m {
+		names = append(names, name)
+	}
+
+	return names
+}
+
+func tableNames(m map[string]*generator.Table) []string {
+	var names []string
+	for name := range m {
+		names = append(names, name)
+	}
+
+	return names
+}
+
+func constraintsByName(constraints []*generator.Constraint) map[string]*generator.Constraint {
+	m := map[string]*generator.Constraint{}
+	for _, c := range constraints {
+		m[c.Name] = c
+	}
+
+	return m
+}
+
+func constraintNames(m map[string]*generator.Constraint) []string {
+	var names []string
+	for name := range m {
+		names = append(names, name)
+	}
+
+	return names
+}
+
+// columnsByPosition returns columns of a table in the order of their positions,
+// as columns are loaded in the order of their names.
+func columnsByPosition(t *generator.Table) []*generator.Column {
+	cols := append([]*generator.Column(nil), t.Columns...)
+	sort.SliceStable(cols, func(i, j int) bool {
+		return cols[i].Position < cols[j].Position
+	})
+
+	return cols
+}
+
+func checksByName(checks []*generator.Check) map[string]*generator.Check {
+	m := map[string]*generator.Check{}
+	for _, check := range checks {
+		m[check.Name] = check
+	}
+
+	return m
+}
+
+func checkNames(m map[string]*generator.Check) []string {
+	var names []string
+	for name := range m {
+		names = append(names, name)
+	}
+
+	return names
+}
+
+func foreignKeysByName(fks []*generator.ForeignKey) map[string]*generator.ForeignKey {
+	m := map[string]*generator.ForeignKey{}
+	for _, fk := range fks {
+		m[fk.Name] = fk
+	}
+
+	return m
+}
+
+func foreignKeyNames(m map[string]*generator.ForeignKey) []string {
+	var names []string
+	for name := range m {
+		names = append(names, name)
+	}
+
+	return names
+}
+
+func indexesByName(indexes []*generator.Index) map[string]*generator.Index {
+	m := map[string]*generator.Index{}
+	for _, index := range indexes {
+		m[index.Name] = index
+	}
+
+	return m
+}
+
+func indexNames(m map[string]*generator.Index) []string {
+	var names []string
+	for name := range m {
+		names = append(names, name)
+	}
+
+	return names
+}
@@ -0,0 +1,222 @@
+package diff_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bongnv/pggo/internal/diff"
+	"github.com/bongnv/pggo/internal/generator"
+)
+
+func fromSchema() *generator.Schema {
+	return &generator.Schema{
+		Enums: map[string]*generator.Enum{
+			"status": {Name: "status", Values: []string{"active", "deleted"}},
+			"mood":   {Name: "mood", Values: []string{"happy"}},
+		},
+		Tables: map[string]*generator.Table{
+			"orgs": {
+				Name: "orgs",
+				Columns: []*generator.Column{
+					{Name: "id", DataType: "bigint"},
+				},
+				PrimaryKey: &generator.Constraint{Name: "orgs_pkey", Columns: []string{"id"}},
+			},
+			"users": {
+				Name: "users",
+				Columns: []*generator.Column{
+					{Name: "id", DataType: "bigint"},
+					{Name: "org_id", DataType: "bigint", Nullable: true},
+					{Name: "name", DataType: "text"},
+					{Name: "age", DataType: "integer", Nullable: true},
+				},
+				PrimaryKey: &generator.Constraint{Name: "users_pkey", Columns: []string{"id"}},
+				UniqueKeys: []*generator.Constraint{
+					{Name: "users_name_key", Columns: []string{"name"}},
+				},
+				ForeignKeys: []*generator.ForeignKey{
+					{Name: "users_org_id_fkey", Columns: []string{"org_id"}, RefTable: "orgs", RefColumns: []string{"id"}},
+				},
+				Indexes: []*generator.Index{
+					{Name: "users_pkey", Primary: true, Unique: true, Columns: []string{"id"}, Definition: "CREATE UNIQUE INDEX users_pkey ON public.users USING btree (id)"},
+					{Name: "users_name_key", Unique: true, Columns: []string{"name"}, Definition: "CREATE UNIQUE INDEX users_name_key ON public.users USING btree (name)"},
+					{Name: "users_age_idx", Columns: []string{"age"}, Definition: "CREATE INDEX users_age_idx ON public.users USING btree (age)"},
+				},
+			},
+		},
+	}
+}
+
+func toSchema() *generator.Schema {
+	return &generator.Schema{
+		Enums: map[string]*generator.Enum{
+			"status": {Name: "status", Values: []string{"pending", "active", "suspended"}},
+			"role":   {Name: "role", Values: []string{"admin", "member"}},
+		},
+		Tables: map[string]*generator.Table{
+			"users": {
+				Name: "users",
+				Columns: []*generator.Column{
+					{Name: "id", DataType: "bigint"},
+					{Name: "name", DataType: "character varying", Nullable: true},
+					{Name: "role", DataType: "role", Default: "'member'::role"},
+				},
+				PrimaryKey: &generator.Constraint{Name: "users_pkey", Columns: []string{"id"}},
+				UniqueKeys: []*generator.Constraint{
+					{Name: "users_name_role_key", Columns: []string{"name", "role"}},
+				},
+				Indexes: []*generator.Index{
+					{Name: "users_pkey", Primary: true, Unique: true, Columns: []string{"id"}, Definition: "CREATE UNIQUE INDEX users_pkey ON public.users USING btree (id)"},
+					{Name: "users_name_role_key", Unique: true, Columns: []string{"name", "role"}, Definition: "CREATE UNIQUE INDEX users_name_role_key ON public.users USING btree (name, role)"},
+					{Name: "users_role_idx", Columns: []string{"role"}, Definition: "CREATE INDEX users_role_idx ON public.users USING btree (role)"},
+				},
+			},
+			"events": {
+				Name: "events",
+				Columns: []*generator.Column{
+					{Name: "id", DataType: "bigint"},
+					{Name: "user", DataType: "bigint"},
+					{Name: "created_at", DataType: "date", Default: "now()"},
+				},
+				PrimaryKey:   &generator.Constraint{Name: "events_pkey", Columns: []string{"id", "created_at"}},
+				ForeignKeys:  []*generator.ForeignKey{{Name: "events_user_fkey", Columns: []string{"user"}, RefTable: "users", RefColumns: []string{"id"}}},
+				PartitionKey: &generator.PartitionKey{Strategy: "range", Columns: []string{"created_at"}, Definition: "RANGE (created_at)"},
+				Partitions:   []string{"events_2021"},
+			},
+			"events_2021": {
+				Name:           "events_2021",
+				Parent:         "events",
+				PartitionBound: "FOR VALUES FROM ('2021-01-01') TO ('2022-01-01')",
+			},
+		},
+	}
+}
+
+func Test_Compare(t *testing.T) {
+	result := diff.Compare(fromSchema(), toSchema())
+	require.False(t, result.Empty())
+	require.Equal(t, `- enum mood
++ enum role (admin, member)
+- enum value status.deleted
++ enum value status.pending
++ enum value status.suspended
++ table events
++ table events_2021
+- table orgs
+- column users.org_id
+- column users.age
+~ column users.name: type text -> character varying
+~ column users.name: nullable NO -> YES
++ column users.role role
+- unique key users.users_name_key
++ unique key users.users_name_role_key (name, role)
+- foreign key users.users_org_id_fkey
+- index users.users_age_idx
++ index users.users_role_idx
+`, result.Report())
+	require.Equal(t, `CREATE TYPE role AS ENUM ('admin', 'member');
+-- unsupported: - enum value status.deleted
+ALTER TYPE status ADD VALUE 'pending' BEFORE 'active';
+ALTER TYPE status ADD VALUE 'suspended' AFTER 'active';
+ALTER TABLE users DROP CONSTRAINT users_org_id_fkey;
+DROP INDEX users_age_idx;
+ALTER TABLE users DROP CONSTRAINT users_name_key;
+DROP TABLE orgs;
+CREATE TABLE events (
+  id bigint NOT NULL,
+  "user" bigint NOT NULL,
+  created_at date NOT NULL DEFAULT now(),
+  CONSTRAINT events_pkey PRIMARY KEY (id, created_at)
+) PARTITION BY RANGE (created_at);
+CREATE TABLE events_2021 PARTITION OF events FOR VALUES FROM ('2021-01-01') TO ('2022-01-01');
+ALTER TABLE users DROP COLUMN org_id;
+ALTER TABLE users DROP COLUMN age;
+ALTER TABLE users ALTER COLUMN name TYPE character varying USING name::character varying;
+ALTER TABLE users ALTER COLUMN name DROP NOT NULL;
+ALTER TABLE users ADD COLUMN role role NOT NULL DEFAULT 'member'::role;
+ALTER TABLE users ADD CONSTRAINT users_name_role_key UNIQUE (name, role);
+CREATE INDEX users_role_idx ON public.users USING btree (role);
+ALTER TABLE events ADD CONSTRAINT events_user_fkey FOREIGN KEY ("user") REFERENCES users (id);
+DROP TYPE mood;
+`, result.SQL())
+}
+
+func Test_Compare_no_changes(t *testing.T) {
+	result := diff.Compare(toSchema(), toSchema())
+	require.True(t, result.Empty())
+	require.Empty(t, result.Report())
+	require.Empty(t, result.SQL())
+}
+
+func Test_Compare_altered_keys(t *testing.T) {
+	from := fromSchema()
+	to := fromSchema()
+	users := to.Tables["users"]
+	users.PrimaryKey.Columns = []string{"id", "name"}
+	users.ForeignKeys[0].RefColumns = []string{"org_id"}
+	users.Indexes[2].Definition = "CREATE INDEX users_age_idx ON public.users USING hash (age)"
+	users.Columns[2].DataType = "character varying"
+	users.Columns[2].MaxLength = 255
+
+	result := diff.Compare(from, to)
+	require.Equal(t, `~ column users.name: type text -> character varying(255)
+~ primary key users.users_pkey: (id) -> (id, name)
+~ foreign key users.users_org_id_fkey
+~ index users.users_age_idx
+`, result.Report())
+	require.Equal(t, `ALTER TABLE users DROP CONSTRAINT users_org_id_fkey;
+DROP INDEX users_age_idx;
+ALTER TABLE users DROP CONSTRAINT users_pkey;
+ALTER TABLE users ALTER COLUMN name TYPE character varying(255) USING name::character varying(255);
+ALTER TABLE users ADD CONSTRAINT users_pkey PRIMARY KEY (id, name);
+CREATE INDEX users_age_idx ON public.users USING hash (age);
+ALTER TABLE users ADD CONSTRAINT users_org_id_fkey FOREIGN KEY (org_id) REFERENCES orgs (org_id);
+`, result.SQL())
+}
+
+func Test_Compare_column_properties(t *testing.T) {
+	from := fromSchema()
+	to := fromSchema()
+	users := to.Tables["users"]
+	users.Columns[0].Identity = "ALWAYS"
+	users.Columns[3].DataType = "numeric"
+	users.Columns[3].NumericPrecision = 10
+	users.Columns[3].NumericScale = 2
+	users.ForeignKeys[0].OnDelete = "CASCADE"
+	users.Checks = []*generator.Check{{Name: "users_age_check", Definition: "CHECK ((age > 0))"}}
+	from.Tables["users"].Checks = []*generator.Check{{Name: "users_name_check", Definition: "CHECK ((name <> ''::text))"}}
+	// columns are loaded in the order of their names, they're created in the order of their positions
+	to.Tables["posts"] = &generator.Table{
+		Name: "posts",
+		Columns: []*generator.Column{
+			{Name: "id", DataType: "integer", Default: "nextval('posts_id_seq'::regclass)", Position: 1},
+			{Name: "at", DataType: "date", Position: 3},
+			{Name: "no", DataType: "bigint", Default: "nextval('post_no_seq'::regclass)", Position: 2},
+		},
+		Checks: []*generator.Check{{Name: "posts_no_check", Definition: "CHECK ((no > 0))"}},
+	}
+
+	result := diff.Compare(from, to)
+	require.Equal(t, `+ table posts
+~ column users.id: identity (none) -> ALWAYS
+~ column users.age: type integer -> numeric(10,2)
++ check users.users_age_check CHECK ((age > 0))
+- check users.users_name_check
+~ foreign key users.users_org_id_fkey
+`, result.Report())
+	require.Equal(t, `-- unsupported: sequence post_no_seq of column posts.no must exist
+ALTER TABLE users DROP CONSTRAINT users_org_id_fkey;
+ALTER TABLE users DROP CONSTRAINT users_name_check;
+CREATE TABLE posts (
+  id serial NOT NULL,
+  no bigint NOT NULL DEFAULT nextval('post_no_seq'::regclass),
+  at date NOT NULL,
+  CONSTRAINT posts_no_check CHECK ((no > 0))
+);
+ALTER TABLE users ALTER COLUMN id ADD GENERATED ALWAYS AS IDENTITY;
+ALTER TABLE users ALTER COLUMN age TYPE numeric(10,2) USING age::numeric(10,2);
+ALTER TABLE users ADD CONSTRAINT users_age_check CHECK ((age > 0));
+ALTER TABLE users ADD CONSTRAINT users_org_id_fkey FOREIGN KEY (org_id) REFERENCES orgs (id) ON DELETE CASCADE;
+`, result.SQL())
+}
@@ -0,0 +1,55 @@
+package generator
+
+import (
+	"bytes"
+
+	"github.com/bongnv/pggo/internal/template"
+)
+
+// DocsGenerator generates Markdown documentation and entity-relationship diagrams from DB schema.
+type DocsGenerator struct {
+	SchemaLoader SchemaLoader
+	Writer       Writer
+}
+
+type docsData struct {
+	Tables []*Table
+}
+
+// Generate generates documentation for all tables in the schema.
+// It writes README.md as the index, a Markdown file per table and ER diagrams in Mermaid and Graphviz DOT.
+func (g *DocsGenerator) Generate() error {
+	schema, err := g.SchemaLoader.Load()
+	if err != nil {
+		return err
+	}
+
+	data := &docsData{
+		Tables: schema.SortedTables(),
+	}
+
+	if err := g.writeFile("README.md", "docs_index.tmpl", data); err != nil {
+		return err
+	}
+
+	for _, table := range data.Tables {
+		if err := g.writeFile(table.Name+".md", "docs_table.tmpl", table); err != nil {
+			return err
+		}
+	}
+
+	if err := g.writeFile("schema.mmd", "docs_mermaid.tmpl", data); err != nil {
+		return err
+	}
+
+	return g.writeFile("schema.dot", "docs_dot.tmpl", data)
+}
+
+func (g *DocsGenerator) writeFile(fileName, tmplName string, data interface{}) error {
+	buf := &bytes.Buffer{}
+	if err := template.Execute(buf, tmplName, data); err != nil {
+		return err
+	}
+
+	return g.Writer.Write(fileName, buf.Bytes())
+}
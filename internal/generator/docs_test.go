@@ -0,0 +1,224 @@
+package generator_test
+
+import (
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bongnv/pggo/internal/generator"
+	"github.com/bongnv/pggo/internal/writer"
+)
+
+func docsSchema() *generator.Schema {
+	return &generator.Schema{
+		Tables: map[string]*generator.Table{
+			"orgs": {
+				Name:    "orgs",
+				Comment: "Organisations using the service.",
+				Columns: []*generator.Column{
+					{Name: "id", DataType: "bigint", Default: "nextval('orgs_id_seq'::regclass)"},
+					{Name: "name", DataType: "character varying"},
+				},
+				PrimaryKey: &generator.Constraint{Name: "orgs_pkey", Columns: []string{"id"}},
+				Indexes: []*generator.Index{
+					{Name: "orgs_pkey", Unique: true, Primary: true, Columns: []string{"id"}, Definition: "CREATE UNIQUE INDEX orgs_pkey ON public.orgs USING btree (id)"},
+				},
+			},
+			"users": {
+				Name: "users",
+				Columns: []*generator.Column{
+					{Name: "id", DataType: "bigint"},
+					{Name: "org_id", DataType: "bigint", Nullable: true, Comment: "Owner | admin org."},
+					{Name: "email", DataType: "text"},
+					{Name: "created_at", DataType: "timestamp with time zone", Default: "now()"},
+				},
+				PrimaryKey: &generator.Constraint{Name: "users_pkey", Columns: []string{"id"}},
+				UniqueKeys: []*generator.Constraint{
+					{Name: "users_email_key", Columns: []string{"email"}},
+				},
+				ForeignKeys: []*generator.ForeignKey{
+					{Name: "users_org_id_fkey", Columns: []string{"org_id"}, RefTable: "orgs", RefColumns: []string{"id"}},
+				},
+			},
+		},
+	}
+}
+
+func Test_DocsGenerator(t *testing.T) {
+	w := writer.NewMemoryWriter()
+	g := &generator.DocsGenerator{
+		SchemaLoader: &mockSchemaLoader{Schema: docsSchema()},
+		Writer:       w,
+	}
+	require.NoError(t, g.Generate())
+
+	readFile := func(name string) string {
+		content, err := fs.ReadFile(w.FS, name)
+		require.NoError(t, err)
+		return string(content)
+	}
+
+	require.Equal(t, "# Database schema\n\n"+
+		"| Table | Comment |\n"+
+		"| --- | --- |\n"+
+		"| [orgs](orgs.md) | Organisations using the service. |\n"+
+		"| [users](users.md) |  |\n"+
+		"\n## Entity-relationship diagram\n\n"+
+		"```mermaid\n"+readFile("schema.mmd")+"```\n", readFile("README.md"))
+
+	require.Equal(t, `# orgs
+
+Organisations using the service.
+
+## Columns
+
+| Name | Type | Nullable | Default | Keys | Comment |
+| --- | --- | --- | --- | --- | --- |
+| id | bigint | NO | nextval('orgs_id_seq'::regclass) | PK |  |
+| name | character varying | NO |  |  |  |
+
+## Primary key
+
+orgs_pkey (id)
+
+## Indexes
+
+| Name | Unique | Definition |
+| --- | --- | --- |
+| orgs_pkey | YES | CREATE UNIQUE INDEX orgs_pkey ON public.orgs USING btree (id) |
+`, readFile("orgs.md"))
+
+	require.Equal(t, `# users
+
+## Columns
+
+| Name | Type | Nullable | Default | Keys | Comment |
+| --- | --- | --- | --- | --- | --- |
+| id | bigint | NO |  | PK |  |
+| org_id | bigint | YES |  | FK | Owner \| admin org. |
+| email | text | NO |  | UK |  |
+| created_at | timestamp with time zone | NO | now() |  |  |
+
+## Primary key
+
+users_pkey (id)
+
+## Unique keys
+
+| Name | Columns |
+| --- | --- |
+| users_email_key | email |
+
+## Foreign keys
+
+| Name | Columns | References |
+| --- | --- | --- |
+| users_org_id_fkey | org_id | [orgs](orgs.md) (id) |
+`, readFile("users.md"))
+
+	require.Equal(t, `erDiagram
+    orgs {
+        bigint id PK
+        character_varying name
+    }
+    users {
+        bigint id PK
+        bigint org_id FK
+        text email UK
+        timestamp_with_time_zone created_at
+    }
+    orgs |o--o{ users : "users_org_id_fkey"
+`, readFile("schema.mmd"))
+
+	require.Equal(t, `digraph schema {
+	rankdir=LR;
+	node [shape=plaintext];
+
+	"orgs" [label=<
+		<table border="0" cellborder="1" cellspacing="0">
+			<tr><td bgcolor="lightgrey"><b>orgs</b></td></tr>
+			<tr><td port="id" align="left">id: bigint (PK)</td></tr>
+			<tr><td port="name" align="left">name: character varying</td></tr>
+		</table>
+	>];
+
+	"users" [label=<
+		<table border="0" cellborder="1" cellspacing="0">
+			<tr><td bgcolor="lightgrey"><b>users</b></td></tr>
+			<tr><td port="id" align="left">id: bigint (PK)</td></tr>
+			<tr><td port="org_id" align="left">org_id: bigint (FK)</td></tr>
+			<tr><td port="email" align="left">email: text (UK)</td></tr>
+			<tr><td port="created_at" align="left">created_at: timestamp with time zone</td></tr>
+		</table>
+	>];
+
+	"users":"org_id" -> "orgs":"id" [label="users_org_id_fkey"];
+}
+`, readFile("schema.dot"))
+}
+
+func Test_DocsGenerator_partitions(t *testing.T) {
+	schema := &generator.Schema{
+		Tables: map[string]*generator.Table{
+			"events": {
+				Name: "events",
+				Columns: []*generator.Column{
+					{Name: "id", DataType: "bigint"},
+					{Name: "created_at", DataType: "date"},
+				},
+				PartitionKey: &generator.PartitionKey{Strategy: "range", Columns: []string{"created_at"}, Definition: "RANGE (created_at)"},
+				Partitions:   []string{"events_2021", "events_2022"},
+			},
+			"events_2021": {
+				Name: "events_2021",
+				Columns: []*generator.Column{
+					{Name: "id", DataType: "bigint"},
+					{Name: "created_at", DataType: "date"},
+				},
+				Parent:         "events",
+				PartitionBound: "FOR VALUES FROM ('2021-01-01') TO ('2022-01-01')",
+			},
+		},
+	}
+
+	w := writer.NewMemoryWriter()
+	g := &generator.DocsGenerator{
+		SchemaLoader: &mockSchemaLoader{Schema: schema},
+		Writer:       w,
+	}
+	require.NoError(t, g.Generate())
+
+	content, err := fs.ReadFile(w.FS, "events.md")
+	require.NoError(t, err)
+	require.Equal(t, `# events
+
+Partitioned by RANGE (created_at).
+
+## Columns
+
+| Name | Type | Nullable | Default | Keys | Comment |
+| --- | --- | --- | --- | --- | --- |
+| id | bigint | NO |  |  |  |
+| created_at | date | NO |  |  |  |
+
+## Partitions
+
+- events_2021
+- events_2022
+`, string(content))
+
+	content, err = fs.ReadFile(w.FS, "events_2021.md")
+	require.NoError(t, err)
+	require.Equal(t, `# events_2021
+
+Partition of [events](events.md) FOR VALUES FROM ('2021-01-01') TO ('2022-01-01').
+
+## Columns
+
+| Name | Type | Nullable | Default | Keys | Comment |
+| --- | --- | --- | --- | --- | --- |
+| id | bigint | NO |  |  |  |
+| created_at | date | NO |  |  |  |
+`, string(content))
+}
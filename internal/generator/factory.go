@@ -0,0 +1,255 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"strconv"
+	"strings"
+)
+
+const fakeImportPath = "github.com/bongnv/pggo/pkg/fake"
+
+type factoryData struct {
+	PackageName string
+	StdImports  []string
+	Imports     []string
+	Table       *Table
+	// Values contains fake values of columns, nullable columns and columns of followed foreign keys are omitted.
+	Values []*factoryValue
+	// Parents contains tables referenced by followed foreign keys.
+	Parents []*Table
+	// ForeignKeys contains foreign keys whose parent rows are created by the factory.
+	ForeignKeys []*factoryForeignKey
+	// Defaults contains columns with defaults, they're read back after inserting if they're left to the database.
+	Defaults []*factoryDefault
+	// Seq is true if fake values are derived from the sequence n of the factory.
+	Seq bool
+	// SharedSeq is true if fake values of keys are derived from the sequence k shared by factories,
+	// so that they don't conflict with existing rows.
+	SharedSeq bool
+}
+
+type factoryValue struct {
+	Column *Column
+	Expr   string
+}
+
+// factoryDefault is a column with a default, it's inserted as DEFAULT unless it's set by overrides.
+type factoryDefault struct {
+	Column *Column
+	// Index is the index of the column in the INSERT query.
+	Index int
+}
+
+type factoryForeignKey struct {
+	Parent     *Table
+	Columns    []*Column
+	RefColumns []*Column
+}
+
+// newFactoryData collects fake values and foreign keys to generate the factory of a table.
+// Only required foreign keys referencing other generated tables are followed.
+func newFactoryData(schema *Schema, t *Table, tables []string) *factoryData {
+	data := &factoryData{
+		PackageName: "model",
+		Table:       t,
+	}
+
+	followed := map[string]bool{}
+	parents := map[string]bool{}
+	for _, fk := range t.ForeignKeys {
+		parent := schema.Tables[fk.RefTable]
+		if parent == nil || parent == t || t.IsOptional(fk) || !containsString(tables, fk.RefTable) {
+			continue
+		}
+
+		cols := t.constraintColumns(&Constraint{Columns: fk.Columns})
+		refCols := parent.constraintColumns(&Constraint{Columns: fk.RefColumns})
+		if len(cols) != len(fk.Columns) || len(refCols) != len(cols) || hasNullable(refCols) {
+			continue
+		}
+
+		data.ForeignKeys = append(data.ForeignKeys, &factoryForeignKey{
+			Parent:     parent,
+			Columns:    cols,
+			RefColumns: refCols,
+		})
+
+		for _, c := range cols {
+			followed[c.Name] = true
+		}
+
+		if !parents[parent.Name] {
+			parents[parent.Name] = true
+			data.Parents = append(data.Parents, parent)
+		}
+	}
+
+	paths := []string{"context"}
+	if len(data.Parents) > 0 {
+		paths = append(paths, "sync", fakeImportPath)
+	}
+
+	for i, c := range t.Columns {
+		if c.HasDefault() {
+			data.Defaults = append(data.Defaults, &factoryDefault{Column: c, Index: i})
+			continue
+		}
+
+		if c.Nullable || followed[c.Name] {
+			continue
+		}
+
+		seq := "n"
+		if isUniqueColumn(t, c) {
+			seq = "k"
+		}
+
+		expr, importPaths := fakeValue(schema, t, c, seq)
+		if expr == "" {
+			continue
+		}
+
+		data.Values = append(data.Values, &factoryValue{
+			Column: c,
+			Expr:   expr,
+		})
+		paths = append(paths, importPaths...)
+		if usesIdent(expr, seq) {
+			data.Seq = data.Seq || seq == "n"
+			data.SharedSeq = data.SharedSeq || seq == "k"
+		}
+	}
+
+	if data.Seq {
+		paths = append(paths, "sync/atomic")
+	}
+
+	if data.SharedSeq || len(data.Defaults) > 0 {
+		paths = append(paths, fakeImportPath)
+	}
+
+	data.StdImports, data.Imports = groupImports(paths)
+	return data
+}
+
+// fingerprint returns a hash of what the factory depends on besides the table itself.
+func (d *factoryData) fingerprint() string {
+	var lines []string
+	for _, v := range d.Values {
+		lines = append(lines, v.Column.Name+" "+v.Expr)
+	}
+
+	for _, fk := range d.ForeignKeys {
+		lines = append(lines, fk.Parent.Name+" "+fk.Parent.GoName())
+	}
+
+	for _, d := range d.Defaults {
+		lines = append(lines, "default "+d.Column.Name)
+	}
+
+	return fingerprint(lines)
+}
+
+// fakeValue returns the Go expression of a fake value of a column given the name of the sequence variable,
+// and import paths it requires. Values are clamped into the range of the column type.
+// The expression is empty if there is no fake value for the type.
+func fakeValue(schema *Schema, t *Table, c *Column, seq string) (string, []string) {
+	if enum := schema.Enums[c.DataType]; enum != nil {
+		values := make([]string, len(enum.Values))
+		for i, v := range enum.Values {
+			values[i] = strconv.Quote(v)
+		}
+
+		return fmt.Sprintf("fake.Enum(%s, %s)", seq, strings.Join(values, ", ")), []string{fakeImportPath}
+	}
+
+	if strings.HasSuffix(c.DataType, "[]") {
+		goType := lookupGoType(c.DataType)
+		switch {
+		case goType.name == "[]interface{}":
+			return "", nil
+		case goType.importPath == "":
+			return goType.name + "{}", nil
+		default:
+			return goType.name + "{}", []string{goType.importPath}
+		}
+	}
+
+	switch c.DataType {
+	case "smallint":
+		return fmt.Sprintf("fake.Int16(%s)", seq), []string{fakeImportPath}
+	case "integer":
+		return fmt.Sprintf("fake.Int32(%s)", seq), []string{fakeImportPath}
+	case "bigint":
+		return seq, nil
+	case "real":
+		return fmt.Sprintf("float32(%s)", seq), nil
+	case "double precision":
+		return fmt.Sprintf("float64(%s)", seq), nil
+	case "numeric":
+		if c.NumericPrecision > 0 {
+			return fmt.Sprintf("fake.Numeric(%s, %d, %d)", seq, c.NumericPrecision, c.NumericScale), []string{fakeImportPath}
+		}
+
+		return fmt.Sprintf("float64(%s)", seq), nil
+	case "boolean":
+		return fmt.Sprintf("%s%%2 == 1", seq), nil
+	case "text", "character varying", "character", "citext":
+		return fmt.Sprintf("fake.String(%q, %s, %d)", c.Name, seq, c.MaxLength), []string{fakeImportPath}
+	case "bytea":
+		return fmt.Sprintf("[]byte(fake.String(%q, %s, 0))", c.Name, seq), []string{fakeImportPath}
+	case "uuid":
+		return fmt.Sprintf("uuid.UUID(fake.UUID(%q, %s))", t.Name+"."+c.Name, seq), []string{fakeImportPath, "github.com/google/uuid"}
+	case "json", "jsonb":
+		return "json.RawMessage(`{}`)", []string{"encoding/json"}
+	case "date":
+		return fmt.Sprintf("fake.Date(%s)", seq), []string{fakeImportPath}
+	case "timestamp without time zone", "timestamp with time zone":
+		return fmt.Sprintf("fake.Time(%s)", seq), []string{fakeImportPath}
+	case "interval":
+		return fmt.Sprintf("fake.Duration(%s)", seq), []string{fakeImportPath}
+	default:
+		return "", nil
+	}
+}
+
+// usesIdent reports whether a Go expression refers to the identifier name.
+func usesIdent(expr, name string) bool {
+	node, err := parser.ParseExpr(expr)
+	if err != nil {
+		return false
+	}
+
+	found := false
+	ast.Inspect(node, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && ident.Name == name {
+			found = true
+		}
+		return !found
+	})
+
+	return found
+}
+
+// isUniqueColumn reports whether a column is in the primary key or a unique key.
+func isUniqueColumn(t *Table, c *Column) bool {
+	for _, key := range t.ColumnKeys(c.Name) {
+		if key == "PK" || key == "UK" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasNullable(cols []*Column) bool {
+	for _, c := range cols {
+		if c.Nullable {
+			return true
+		}
+	}
+
+	return false
+}
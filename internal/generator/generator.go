@@ -2,27 +2,141 @@ package generator
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"go/format"
+	"io"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
 
 	"github.com/bongnv/pggo/internal/template"
 )
 
 // Column represents a column in a table.
 type Column struct {
-	Name     string
-	Nullable bool
-	DataType string
+	Name     string `json:"name"`
+	Nullable bool   `json:"nullable,omitempty"`
+	DataType string `json:"data_type"`
+	// MaxLength is the declared length of a character type, it's zero if the length isn't limited.
+	MaxLength int `json:"max_length,omitempty"`
+	// NumericPrecision and NumericScale are declared for numeric columns, they're zero if the precision isn't limited.
+	NumericPrecision int `json:"numeric_precision,omitempty"`
+	NumericScale     int `json:"numeric_scale,omitempty"`
+	// Identity is ALWAYS or BY DEFAULT for identity columns, it's empty otherwise.
+	Identity string `json:"identity,omitempty"`
+	// Default is the default expression of the column, it's empty if there is no default.
+	Default string `json:"default,omitempty"`
+	Comment string `json:"comment,omitempty"`
+	// Position is the ordinal position of the column in its table, positions of dropped columns aren't reused.
+	Position int `json:"position,omitempty"`
+}
+
+// Constraint represents a primary key or unique constraint of a table.
+type Constraint struct {
+	Name    string   `json:"name"`
+	Columns []string `json:"columns,omitempty"`
+}
+
+// ForeignKey represents a foreign key constraint of a table.
+type ForeignKey struct {
+	Name       string   `json:"name"`
+	Columns    []string `json:"columns,omitempty"`
+	RefTable   string   `json:"ref_table,omitempty"`
+	RefColumns []string `json:"ref_columns,omitempty"`
+	// OnDelete and OnUpdate are referential actions, e.g. CASCADE. They're empty for NO ACTION.
+	OnDelete string `json:"on_delete,omitempty"`
+	OnUpdate string `json:"on_update,omitempty"`
+}
+
+// Index represents an index of a table.
+type Index struct {
+	Name    string `json:"name"`
+	Unique  bool   `json:"unique,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+	// Columns contains names of indexed columns, expressions are omitted.
+	Columns    []string `json:"columns,omitempty"`
+	Definition string   `json:"definition,omitempty"`
+}
+
+// PartitionKey represents the partition key of a partitioned table.
+type PartitionKey struct {
+	// Strategy is the partitioning strategy: range, list or hash.
+	Strategy string `json:"strategy"`
+	// Columns contains names of columns in the key, expressions are omitted.
+	Columns    []string `json:"columns,omitempty"`
+	Definition string   `json:"definition,omitempty"`
 }
 
 // Table represents a table in a schema.
 type Table struct {
-	Name    string
-	Columns []*Column
+	Name        string        `json:"name"`
+	Comment     string        `json:"comment,omitempty"`
+	Columns     []*Column     `json:"columns,omitempty"`
+	PrimaryKey  *Constraint   `json:"primary_key,omitempty"`
+	UniqueKeys  []*Constraint `json:"unique_keys,omitempty"`
+	ForeignKeys []*ForeignKey `json:"foreign_keys,omitempty"`
+	Checks      []*Check      `json:"checks,omitempty"`
+	Indexes     []*Index      `json:"indexes,omitempty"`
+	// Parent is the name of the partitioned table or the inherited table if the table is a partition or a child.
+	Parent string `json:"parent,omitempty"`
+	// PartitionBound is the bound of a partition, e.g. "FOR VALUES IN ('a')".
+	PartitionBound string `json:"partition_bound,omitempty"`
+	// PartitionKey is the partition key if the table is partitioned.
+	PartitionKey *PartitionKey `json:"partition_key,omitempty"`
+	// Partitions contains names of partitions or inheritance children of the table.
+	Partitions []string `json:"partitions,omitempty"`
+}
+
+// Check is a CHECK constraint of a table.
+type Check struct {
+	Name string `json:"name"`
+	// Definition is the definition of the constraint, e.g. "CHECK ((price > 0))".
+	Definition string `json:"definition"`
+}
+
+// Query commands define how results of a query are returned.
+const (
+	// QueryOne returns a single row.
+	QueryOne = "one"
+	// QueryMany returns a list of rows.
+	QueryMany = "many"
+	// QueryExec returns no rows.
+	QueryExec = "exec"
+	// QueryExecRows returns the number of affected rows.
+	QueryExecRows = "execrows"
+)
+
+// Query represents an annotated SQL query, e.g. "-- name: ListUsers :many".
+type Query struct {
+	Name    string `json:"name"`
+	Command string `json:"command,omitempty"`
+	SQL     string `json:"sql,omitempty"`
+	// File is the name of the SQL file without extension where the query is defined.
+	File    string    `json:"file,omitempty"`
+	Params  []*Column `json:"params,omitempty"`
+	Results []*Column `json:"results,omitempty"`
+	// Nullable and NotNull contain results declared by "-- nullable: name" annotations and "name!" aliases,
+	// they override the nullability inferred from the query.
+	Nullable []string `json:"nullable,omitempty"`
+	NotNull  []string `json:"not_null,omitempty"`
+}
+
+// Enum represents an enum type.
+type Enum struct {
+	Name   string   `json:"name"`
+	Values []string `json:"values,omitempty"`
 }
 
 // Scheme represents a DB schema.
 type Schema struct {
-	Tables map[string]*Table
+	Tables  map[string]*Table `json:"tables,omitempty"`
+	Enums   map[string]*Enum  `json:"enums,omitempty"`
+	Queries []*Query          `json:"queries,omitempty"`
 }
 
 // SchemaLoader is an interface that wraps Load method.
@@ -39,68 +153,476 @@ type Writer interface {
 
 type templateData struct {
 	PackageName string
+	StdImports  []string
+	Imports     []string
 	Table       *Table
+
+	tags      []Tag
+	overrides map[string]ColumnOverride
+}
+
+// FieldTag returns the struct tag of the field representing a column of the table.
+func (d *templateData) FieldTag(c *Column) string {
+	return structTag(d.tags, d.overrides[d.Table.Name+"."+c.Name], c)
+}
+
+type queryFileData struct {
+	PackageName string
+	StdImports  []string
+	Imports     []string
+	Queries     []*Query
+}
+
+// Cache stores content hashes of generated tables, so that unchanged tables are skipped.
+type Cache interface {
+	// Hash returns the hash of a table generated in the last run, it's empty if the table isn't cached.
+	Hash(table string) string
+	// SetHash records the hash of a generated table.
+	SetHash(table, hash string)
 }
 
 // Generator is an implementation to generate Go code from schema.
 type Generator struct {
 	SchemaLoader SchemaLoader
-	Table        string
-	Writer       Writer
+	// Tables contains names of tables for generating code.
+	Tables []string
+	// All generates code for all tables in the schema.
+	All    bool
+	Writer Writer
+	// Repositories enables generating repository interfaces, implementations and in-memory fakes.
+	Repositories bool
+	// Factories enables generating factories of test data.
+	Factories bool
+	// Proto enables generating .proto files of tables and converters between models and protobuf messages if it's set.
+	Proto *ProtoOptions
+	// Tags defines struct tags generated for fields of models.
+	Tags []Tag
+	// Overrides customises code generated for columns, keyed by "table.column".
+	Overrides map[string]ColumnOverride
+	// Workers is the number of tables rendered concurrently, it defaults to the number of CPUs.
+	Workers int
+	// Cache skips tables whose schema and templates are unchanged since the last run if it's set.
+	Cache Cache
+	// OutputDir is the directory which Writer writes files into, it's part of hashes stored in Cache.
+	OutputDir string
+}
+
+// generatedFile is a rendered file waiting to be written.
+type generatedFile struct {
+	name    string
+	content []byte
+}
 
-	data *templateData
+type tableDefinitionsData struct {
+	PackageName string
+	Tables      []*Table
+}
+
+// tableOutput is the result of rendering a table.
+type tableOutput struct {
+	files []*generatedFile
+	hash  string
+	err   error
 }
 
 // Generate generates Go code from DB schema.
 func (g *Generator) Generate() error {
-	steps := []func() error{
-		g.prepareData,
-		g.genModels,
-		g.genSchema,
+	if err := g.validate(); err != nil {
+		return err
 	}
 
-	for _, s := range steps {
-		if err := s(); err != nil {
+	schema, err := g.SchemaLoader.Load()
+	if err != nil {
+		return err
+	}
+
+	tables := g.tableNames(schema)
+	if len(tables) == 0 && len(schema.Queries) == 0 {
+		return errors.New("generator: there is no table or query to generate code")
+	}
+
+	return g.generate(schema, tables, schema.Queries)
+}
+
+func (g *Generator) validate() error {
+	if err := validateTags(g.Tags); err != nil {
+		return err
+	}
+
+	return g.Proto.validate()
+}
+
+// tableNames returns names of tables for generating code.
+func (g *Generator) tableNames(schema *Schema) []string {
+	if !g.All {
+		return g.Tables
+	}
+
+	var names []string
+	for _, t := range schema.SortedTables() {
+		names = append(names, t.Name)
+	}
+
+	return names
+}
+
+// generate generates Go code for the given tables and queries of a loaded schema.
+// Tables are rendered concurrently but files are written in the order of tables, so the output is deterministic.
+func (g *Generator) generate(schema *Schema, tables []string, queries []*Query) error {
+	for i, output := range g.renderTables(schema, tables) {
+		if output.err != nil {
+			return output.err
+		}
+
+		for _, f := range output.files {
+			if err := g.Writer.Write(f.name, f.content); err != nil {
+				return err
+			}
+		}
+
+		if g.Cache != nil && output.hash != "" {
+			g.Cache.SetHash(tables[i], output.hash)
+		}
+	}
+
+	if len(tables) > 0 {
+		if err := g.genTableDefinitions(schema); err != nil {
 			return err
 		}
 	}
 
-	return nil
+	return g.genQueries(queries)
 }
 
-func (g *Generator) prepareData() error {
-	schema, err := g.SchemaLoader.Load()
+// genTableDefinitions generates the list of definitions of all generated tables, including ones which are unchanged.
+func (g *Generator) genTableDefinitions(schema *Schema) error {
+	data := &tableDefinitionsData{
+		PackageName: "model",
+	}
+
+	for _, name := range g.tableNames(schema) {
+		if t := schema.Tables[name]; t != nil {
+			data.Tables = append(data.Tables, t)
+		}
+	}
+
+	f, err := renderGoFile("table_definitions.pggo.go", "table_definitions.tmpl", data)
 	if err != nil {
 		return err
 	}
 
-	table := schema.Tables[g.Table]
+	return g.Writer.Write(f.name, f.content)
+}
+
+// renderTables renders tables using a pool of workers. Outputs are in the same order as tables.
+func (g *Generator) renderTables(schema *Schema, tables []string) []*tableOutput {
+	workers := g.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	outputs := make([]*tableOutput, len(tables))
+	indexes := make(chan int)
+	wg := &sync.WaitGroup{}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				outputs[i] = g.renderTable(schema, tables[i])
+			}
+		}()
+	}
+
+	for i := range tables {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return outputs
+}
+
+func (g *Generator) renderTable(schema *Schema, name string) *tableOutput {
+	table := schema.Tables[name]
 	if table == nil {
-		return fmt.Errorf("generator: %s couldn't be found in the schema", g.Table)
+		return &tableOutput{
+			err: fmt.Errorf("generator: %s couldn't be found in the schema", name),
+		}
+	}
+
+	output := &tableOutput{}
+	if g.Cache != nil {
+		output.hash = g.tableHash(schema, table)
+		if g.Cache.Hash(name) == output.hash {
+			// files are unchanged, there is no need to record the hash again
+			output.hash = ""
+			return output
+		}
 	}
 
-	g.data = &templateData{
+	data := &templateData{
 		PackageName: "model",
-		Table:       schema.Tables[g.Table],
+		Table:       table,
+		tags:        g.Tags,
+		overrides:   g.Overrides,
+	}
+	data.StdImports, data.Imports = groupImports(append([]string{"fmt"}, importPaths(table.Columns)...))
+
+	steps := []func(data *templateData) ([]*generatedFile, error){
+		g.genModels,
+		g.genSchema,
+		g.genRepositories,
+		func(data *templateData) ([]*generatedFile, error) {
+			return g.genFactories(schema, data)
+		},
+		func(data *templateData) ([]*generatedFile, error) {
+			return g.genProto(schema, data)
+		},
+	}
+
+	for _, s := range steps {
+		files, err := s(data)
+		if err != nil {
+			output.err = err
+			return output
+		}
+
+		output.files = append(output.files, files...)
+	}
+
+	return output
+}
+
+// tableHash returns a hash of everything affecting files generated for a table.
+func (g *Generator) tableHash(schema *Schema, t *Table) string {
+	overrides := map[string]ColumnOverride{}
+	for key, o := range g.Overrides {
+		if strings.HasPrefix(key, t.Name+".") {
+			overrides[key] = o
+		}
+	}
+
+	// factories depend on enums and other generated tables
+	var factory string
+	if g.Factories {
+		factory = newFactoryData(schema, t, g.tableNames(schema)).fingerprint()
+	}
+
+	return fingerprint(struct {
+		Build        string
+		OutputDir    string
+		Templates    string
+		Table        *Table
+		Repositories bool
+		Factory      string
+		Proto        *ProtoOptions
+		Enums        []*Enum
+		Tags         []Tag
+		Overrides    map[string]ColumnOverride
+	}{
+		Build:        getBuildID(),
+		OutputDir:    g.OutputDir,
+		Templates:    template.Fingerprint(),
+		Table:        t,
+		Repositories: g.Repositories,
+		Factory:      factory,
+		Proto:        g.Proto,
+		Enums:        schema.enumsOf(t),
+		Tags:         g.Tags,
+		Overrides:    overrides,
+	})
+}
+
+const modulePath = "github.com/bongnv/pggo"
+
+var buildID struct {
+	once sync.Once
+	id   string
+}
+
+// getBuildID returns the version of pggo, as the Go code mapping columns to types affects generated files too.
+// Development builds don't have versions, they're identified by the content of the executable.
+func getBuildID() string {
+	buildID.once.Do(func() {
+		buildID.id = moduleVersion()
+		if buildID.id == "" {
+			buildID.id = executableHash()
+		}
+	})
+
+	return buildID.id
+}
+
+func moduleVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+
+	modules := append([]*debug.Module{&info.Main}, info.Deps...)
+	for _, m := range modules {
+		if m.Path == modulePath && m.Version != "" && m.Version != "(devel)" {
+			return m.Version + " " + m.Sum
+		}
+	}
+
+	return ""
+}
+
+func executableHash() string {
+	fileName, err := os.Executable()
+	if err != nil {
+		return ""
+	}
+
+	f, err := os.Open(fileName)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return ""
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (g *Generator) genModels(data *templateData) ([]*generatedFile, error) {
+	return renderGoFiles(data.Table.Name+".pggo.go", "table_model.tmpl", data)
+}
+
+func (g *Generator) genSchema(data *templateData) ([]*generatedFile, error) {
+	return renderGoFiles("schema/"+data.Table.Name+".pggo.go", "table_schema.tmpl", data)
+}
+
+func (g *Generator) genQueries(queries []*Query) error {
+	var files []string
+	queriesByFile := map[string][]*Query{}
+	for _, q := range queries {
+		if _, ok := queriesByFile[q.File]; !ok {
+			files = append(files, q.File)
+		}
+		queriesByFile[q.File] = append(queriesByFile[q.File], q)
+	}
+
+	for _, file := range files {
+		queries := queriesByFile[file]
+		data := &queryFileData{
+			PackageName: "model",
+			Queries:     queries,
+		}
+
+		paths := []string{"context"}
+		for _, q := range queries {
+			paths = append(paths, importPaths(q.Params, q.Results)...)
+			if q.ReturnsRows() {
+				paths = append(paths, "fmt")
+			}
+		}
+		data.StdImports, data.Imports = groupImports(paths)
+
+		f, err := renderGoFile(file+".sql.pggo.go", "queries.tmpl", data)
+		if err != nil {
+			return err
+		}
+
+		if err := g.Writer.Write(f.name, f.content); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
-func (g *Generator) genModels() error {
-	buf := &bytes.Buffer{}
-	if err := template.Execute(buf, "table_model.tmpl", g.data); err != nil {
-		return err
+func (g *Generator) genRepositories(tableData *templateData) ([]*generatedFile, error) {
+	if !g.Repositories {
+		return nil, nil
 	}
 
-	return g.Writer.Write(g.Table+".pggo.go", buf.Bytes())
+	keyPaths := importPaths(tableData.Table.PrimaryKeyColumns())
+
+	data := *tableData
+	data.StdImports, data.Imports = groupImports(append([]string{"context"}, keyPaths...))
+	repository, err := renderGoFile(data.Table.Name+"_repository.pggo.go", "table_repository.tmpl", &data)
+	if err != nil {
+		return nil, err
+	}
+
+	fakeData := *tableData
+	fakeData.StdImports, fakeData.Imports = groupImports(append([]string{"context", "fmt", "sync"}, keyPaths...))
+	fake, err := renderGoFile(data.Table.Name+"_fake.pggo.go", "table_fake.tmpl", &fakeData)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*generatedFile{repository, fake}, nil
 }
 
-func (g *Generator) genSchema() error {
+func (g *Generator) genFactories(schema *Schema, tableData *templateData) ([]*generatedFile, error) {
+	if !g.Factories {
+		return nil, nil
+	}
+
+	data := newFactoryData(schema, tableData.Table, g.tableNames(schema))
+	return renderGoFiles(data.Table.Name+"_factory.pggo.go", "table_factory.tmpl", data)
+}
+
+func (g *Generator) genProto(schema *Schema, tableData *templateData) ([]*generatedFile, error) {
+	if g.Proto == nil {
+		return nil, nil
+	}
+
+	data := newProtoData(schema, tableData.Table, g.Proto)
+	proto, err := renderFile("proto/"+data.Table.Name+".proto", "table_proto.tmpl", data)
+	if err != nil {
+		return nil, err
+	}
+
+	converter, err := renderGoFile(data.Table.Name+"_proto.pggo.go", "table_proto_converter.tmpl", data)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*generatedFile{proto, converter}, nil
+}
+
+// renderGoFiles renders a single Go file as a list.
+func renderGoFiles(fileName, tmplName string, data interface{}) ([]*generatedFile, error) {
+	f, err := renderGoFile(fileName, tmplName, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*generatedFile{f}, nil
+}
+
+// renderGoFile executes a template and formats the Go code.
+func renderGoFile(fileName, tmplName string, data interface{}) (*generatedFile, error) {
+	f, err := renderFile(fileName, tmplName, data)
+	if err != nil {
+		return nil, err
+	}
+
+	f.content, err = format.Source(f.content)
+	if err != nil {
+		return nil, fmt.Errorf("generator: failed to format %s: %w", fileName, err)
+	}
+
+	return f, nil
+}
+
+// renderFile executes a template.
+func renderFile(fileName, tmplName string, data interface{}) (*generatedFile, error) {
 	buf := &bytes.Buffer{}
-	if err := template.Execute(buf, "table_schema.tmpl", g.data); err != nil {
-		return err
+	if err := template.Execute(buf, tmplName, data); err != nil {
+		return nil, err
 	}
 
-	return g.Writer.Write("schema/"+g.Table+".pggo.go", buf.Bytes())
+	return &generatedFile{
+		name:    fileName,
+		content: buf.Bytes(),
+	}, nil
 }
@@ -4,11 +4,13 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"io/fs"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/bongnv/pggo/internal/generator"
+	"github.com/bongnv/pggo/internal/writer"
 )
 
 type mockSchemaLoader struct {
@@ -46,6 +48,7 @@ func Test_Generator_happy(t *testing.T) {
 						},
 						{
 							Name:     "name",
+							Nullable: true,
 							DataType: "text",
 						},
 					},
@@ -56,7 +59,7 @@ func Test_Generator_happy(t *testing.T) {
 	writer := &mockWriter{}
 	g := &generator.Generator{
 		SchemaLoader: loader,
-		Table:        "mock_table",
+		Tables:       []string{"mock_table"},
 		Writer:       writer,
 	}
 	require.NoError(t, g.Generate())
@@ -64,21 +67,91 @@ func Test_Generator_happy(t *testing.T) {
 package model
 
 import (
+	"fmt"
+
 	"github.com/google/uuid"
+
+	"github.com/bongnv/pggo/pkg/sqlb"
 )
 
-// SampleTable represents mock_table table.
-type SampleTable struct {
+// MockTable represents mock_table table.
+type MockTable struct {
 	ID   uuid.UUID
-	Name string
+	Name *string
+}
+
+// GetPointers returns pointers for storing data from the given columns.
+func (e *MockTable) GetPointers(cols []string) ([]interface{}, error) {
+	if len(cols) == 0 {
+		return []interface{}{&e.ID, &e.Name}, nil
+	}
+
+	pointers := make([]interface{}, len(cols))
+	for i, col := range cols {
+		switch col {
+		case "id":
+			pointers[i] = &e.ID
+		case "name":
+			pointers[i] = &e.Name
+		default:
+			return nil, fmt.Errorf("MockTable: column %s doesn't exist", col)
+		}
+	}
+
+	return pointers, nil
+}
+
+// GetValues returns values of the given columns.
+func (e *MockTable) GetValues(cols []string) ([]interface{}, error) {
+	if len(cols) == 0 {
+		return []interface{}{e.ID, e.Name}, nil
+	}
+
+	values := make([]interface{}, len(cols))
+	for i, col := range cols {
+		switch col {
+		case "id":
+			values[i] = e.ID
+		case "name":
+			values[i] = e.Name
+		default:
+			return nil, fmt.Errorf("MockTable: column %s doesn't exist", col)
+		}
+	}
+
+	return values, nil
+}
+
+// MockTableList represents a list of MockTable.
+type MockTableList []*MockTable
+
+// New creates a new MockTable.
+func (l MockTableList) New() sqlb.Entity {
+	return &MockTable{}
+}
+
+// Append adds a MockTable into the list.
+func (l *MockTableList) Append(e sqlb.Entity) {
+	*l = append(*l, e.(*MockTable))
+}
+
+// MockTableDefinition is the structure of mock_table table when the code was generated.
+// It's listed in TableDefinitions to be verified by sqlb.VerifySchema.
+var MockTableDefinition = &sqlb.TableDefinition{
+	Name:        "mock_table",
+	Fingerprint: "01a685f4ef510a819a152242b5ca6b80f066f758725a7e99359347c4b1282fcd",
+	Columns: []sqlb.ColumnDefinition{
+		{Name: "id", DataType: "uuid"},
+		{Name: "name", DataType: "text", Nullable: true},
+	},
 }
 schema/mock_table.pggo.go
 package schema
 
 import "github.com/bongnv/pggo/pkg/sqlb"
 
-// SampleTable defines the schema of mock_table.
-var SampleTable = struct {
+// MockTable defines the schema of mock_table.
+var MockTable = struct {
 	sqlb.BaseTable
 	ID   string
 	Name string
@@ -87,10 +160,334 @@ var SampleTable = struct {
 	ID:        "id",
 	Name:      "name",
 }
+table_definitions.pggo.go
+package model
+
+import "github.com/bongnv/pggo/pkg/sqlb"
+
+// TableDefinitions contains structures of tables generated into the package,
+// verify them against the live DB schema via sqlb.VerifySchema.
+var TableDefinitions = []*sqlb.TableDefinition{
+	MockTableDefinition,
+}
 `,
 		writer.String())
 }
 
+func Test_Generator_repositories(t *testing.T) {
+	loader := &mockSchemaLoader{
+		Schema: &generator.Schema{
+			Tables: map[string]*generator.Table{
+				"users": {
+					Name: "users",
+					Columns: []*generator.Column{
+						{Name: "id", DataType: "bigint"},
+						{Name: "email", DataType: "text"},
+						{Name: "handle", DataType: "text", Nullable: true},
+						{Name: "tags", DataType: "text[]"},
+						{Name: "joined_at", DataType: "timestamp with time zone", Nullable: true},
+						{Name: "created_at", DataType: "timestamp with time zone", Default: "now()"},
+					},
+					PrimaryKey: &generator.Constraint{Name: "users_pkey", Columns: []string{"id"}},
+					UniqueKeys: []*generator.Constraint{
+						{Name: "users_email_key", Columns: []string{"email"}},
+						{Name: "users_handle_key", Columns: []string{"handle"}},
+						{Name: "users_joined_at_key", Columns: []string{"joined_at"}},
+					},
+				},
+			},
+		},
+	}
+	w := writer.NewMemoryWriter()
+	g := &generator.Generator{
+		SchemaLoader: loader,
+		Tables:       []string{"users"},
+		Writer:       w,
+		Repositories: true,
+	}
+	require.NoError(t, g.Generate())
+
+	repository, err := fs.ReadFile(w.FS, "users_repository.pggo.go")
+	require.NoError(t, err)
+	require.Contains(t, string(repository), "type UsersRepository interface {")
+	require.Contains(t, string(repository), "func NewUsersRepository(factory sqlb.Factory) UsersRepository {")
+	require.Contains(t, string(repository), "const usersUpdateSQL = `UPDATE users SET email = $1, handle = $2, tags = $3, joined_at = $4, created_at = $5 WHERE id = $6`")
+	require.Contains(t, string(repository), `	values[5] = sqlb.DefaultIfZero(values[5])
+
+	err = r.factory.InsertTable("users").
+		Columns(usersColumns...).
+		Values(values...).
+		Returning("created_at").
+		QueryRow(ctx, e)
+	return sqlb.WrapDuplicateKey(err)`)
+	require.Contains(t, string(repository), "return sqlb.WrapDuplicateKey(r.exec(ctx, usersUpdateSQL, args))")
+	require.Contains(t, string(repository), "const usersDeleteSQL = `DELETE FROM users WHERE id = $1`")
+
+	fake, err := fs.ReadFile(w.FS, "users_fake.pggo.go")
+	require.NoError(t, err)
+	require.Contains(t, string(fake), "func NewInMemoryUsersRepository() *InMemoryUsersRepository {")
+	require.Contains(t, string(fake), `		{constraint: "users_pkey", value: usersPrimaryKey(e.ID)},
+		{constraint: "users_email_key"},
+		{constraint: "users_handle_key"},
+		{constraint: "users_joined_at_key"},
+	}
+
+	keys[1].value = fmt.Sprintf("%#v", []interface{}{e.Email})
+
+	if e.Handle != nil {
+		keys[2].value = fmt.Sprintf("%#v", []interface{}{*e.Handle})
+	}
+
+	if e.JoinedAt != nil {
+		keys[3].value = fmt.Sprintf("%#v", []interface{}{e.JoinedAt.UTC()})
+	}
+`)
+	require.Contains(t, string(fake), `func usersClone(e *Users) *Users {
+	row := *e
+	if e.Handle != nil {
+		v := *e.Handle
+		row.Handle = &v
+	}
+	if e.Tags != nil {
+		row.Tags = append(e.Tags[:0:0], e.Tags...)
+	}
+	if e.JoinedAt != nil {
+		v := *e.JoinedAt
+		row.JoinedAt = &v
+	}
+
+	return &row
+}`)
+}
+
+func Test_Generator_tags(t *testing.T) {
+	loader := &mockSchemaLoader{
+		Schema: &generator.Schema{
+			Tables: map[string]*generator.Table{
+				"users": {
+					Name: "users",
+					Columns: []*generator.Column{
+						{Name: "id", DataType: "bigint"},
+						{Name: "nick_name", DataType: "text", Nullable: true},
+						{Name: "password_hash", DataType: "text"},
+					},
+				},
+			},
+		},
+	}
+	w := writer.NewMemoryWriter()
+	g := &generator.Generator{
+		SchemaLoader: loader,
+		Tables:       []string{"users"},
+		Writer:       w,
+		Tags: []generator.Tag{
+			{Key: "json", Style: generator.StyleCamel, OmitEmpty: true},
+			{Key: "db"},
+			{Key: "yaml", Style: generator.StyleSnake},
+		},
+		Overrides: map[string]generator.ColumnOverride{
+			"users.password_hash": {
+				Tags: map[string]string{"json": "-"},
+			},
+		},
+	}
+	require.NoError(t, g.Generate())
+
+	model, err := fs.ReadFile(w.FS, "users.pggo.go")
+	require.NoError(t, err)
+	require.Contains(t, string(model), `type Users struct {
+	ID           int64   `+"`"+`json:"id" db:"id" yaml:"id"`+"`"+`
+	NickName     *string `+"`"+`json:"nickName,omitempty" db:"nick_name" yaml:"nick_name"`+"`"+`
+	PasswordHash string  `+"`"+`json:"-" db:"password_hash" yaml:"password_hash"`+"`"+`
+}`)
+}
+
+func Test_Generator_factories(t *testing.T) {
+	loader := &mockSchemaLoader{
+		Schema: &generator.Schema{
+			Enums: map[string]*generator.Enum{
+				"role": {Name: "role", Values: []string{"admin", "member"}},
+			},
+			Tables: map[string]*generator.Table{
+				"orgs": {
+					Name: "orgs",
+					Columns: []*generator.Column{
+						{Name: "id", DataType: "uuid"},
+						{Name: "name", DataType: "character varying", MaxLength: 20},
+					},
+					PrimaryKey: &generator.Constraint{Name: "orgs_pkey", Columns: []string{"id"}},
+				},
+				"users": {
+					Name: "users",
+					Columns: []*generator.Column{
+						{Name: "id", DataType: "bigint"},
+						{Name: "org_id", DataType: "uuid"},
+						{Name: "role", DataType: "role"},
+						{Name: "nick_name", DataType: "text", Nullable: true},
+						{Name: "invited_by", DataType: "bigint", Nullable: true},
+						{Name: "created_at", DataType: "timestamp with time zone", Default: "now()"},
+					},
+					ForeignKeys: []*generator.ForeignKey{
+						{Name: "users_org_id_fkey", Columns: []string{"org_id"}, RefTable: "orgs", RefColumns: []string{"id"}},
+						{Name: "users_invited_by_fkey", Columns: []string{"invited_by"}, RefTable: "users", RefColumns: []string{"id"}},
+					},
+				},
+			},
+		},
+	}
+	w := writer.NewMemoryWriter()
+	g := &generator.Generator{
+		SchemaLoader: loader,
+		All:          true,
+		Writer:       w,
+		Factories:    true,
+	}
+	require.NoError(t, g.Generate())
+
+	orgs, err := fs.ReadFile(w.FS, "orgs_factory.pggo.go")
+	require.NoError(t, err)
+	require.Contains(t, string(orgs), `	n := atomic.AddInt64(&f.seq, 1)
+	k := fake.Seq()
+	e := &Orgs{
+		ID:   uuid.UUID(fake.UUID("orgs.id", k)),
+		Name: fake.String("name", n, 20),
+	}`)
+	require.NotContains(t, string(orgs), "createParents")
+	require.NotContains(t, string(orgs), "Returning")
+
+	users, err := fs.ReadFile(w.FS, "users_factory.pggo.go")
+	require.NoError(t, err)
+	require.Contains(t, string(users), "func NewUsersFactory(factory sqlb.Factory) *UsersFactory {")
+	require.Contains(t, string(users), `	e := &Users{
+		ID:   n,
+		Role: fake.Enum(n, "admin", "member"),
+	}`)
+	require.Contains(t, string(users), `	if fake.IsZero(e.OrgID) {
+		parent, err := f.parentOrgs().Create(ctx)
+		if err != nil {
+			return err
+		}
+
+		e.OrgID = parent.ID
+	}`)
+	require.Contains(t, string(users), `	n := atomic.AddInt64(&f.seq, 1)
+	e := &Users{`)
+	require.Contains(t, string(users), `	if count <= 0 {
+		return nil, nil
+	}`)
+	require.Contains(t, string(users), `Columns("id", "org_id", "role", "nick_name", "invited_by", "created_at")`)
+	require.Contains(t, string(users), `		if fake.IsZero(e.CreatedAt) {
+			values[5] = sqlb.Default
+		}`)
+	require.Contains(t, string(users), `	err := insert.
+		Returning("created_at").
+		Query(ctx, &usersInserted{entities: entities})`)
+	require.Contains(t, string(users), `	if fake.IsZero(entity.CreatedAt) {
+		entity.CreatedAt = returned.CreatedAt
+	}`)
+	require.NotContains(t, string(users), "parentUsers")
+}
+
+func Test_Generator_proto(t *testing.T) {
+	loader := &mockSchemaLoader{
+		Schema: &generator.Schema{
+			Tables: map[string]*generator.Table{
+				"users": {
+					Name: "users",
+					Columns: []*generator.Column{
+						{Name: "id", DataType: "uuid", Position: 1},
+						{Name: "age", DataType: "smallint", Nullable: true, Position: 2},
+						{Name: "email", DataType: "text", Comment: "Email to sign in.", Position: 3},
+						{Name: "created_at", DataType: "timestamp with time zone", Position: 6},
+						{Name: "location", DataType: "point", Position: 7},
+					},
+				},
+			},
+		},
+	}
+	w := writer.NewMemoryWriter()
+	g := &generator.Generator{
+		SchemaLoader: loader,
+		Tables:       []string{"users"},
+		Writer:       w,
+		Proto: &generator.ProtoOptions{
+			Package:   "app.v1",
+			GoPackage: "example.com/app/pb;pb",
+		},
+	}
+	require.NoError(t, g.Generate())
+
+	proto, err := fs.ReadFile(w.FS, "proto/users.proto")
+	require.NoError(t, err)
+	require.Equal(t, `syntax = "proto3";
+
+package app.v1;
+
+import "google/protobuf/timestamp.proto";
+import "google/protobuf/wrappers.proto";
+
+option go_package = "example.com/app/pb;pb";
+
+// Users represents users table.
+message Users {
+  string id = 1;
+  google.protobuf.Int32Value age = 2;
+  // Email to sign in.
+  string email = 3;
+  google.protobuf.Timestamp created_at = 6;
+  // location is omitted as point isn't supported.
+  reserved 7;
+  // Numbers of dropped columns aren't reused.
+  reserved 4, 5;
+}
+`, string(proto))
+
+	converter, err := fs.ReadFile(w.FS, "users_proto.pggo.go")
+	require.NoError(t, err)
+	require.Contains(t, string(converter), `	pb "example.com/app/pb"`)
+	require.Contains(t, string(converter), `	m := &pb.Users{
+		Id:        e.ID.String(),
+		Email:     e.Email,
+		CreatedAt: timestamppb.New(e.CreatedAt),
+	}
+
+	if e.Age != nil {
+		m.Age = wrapperspb.Int32(int32(*e.Age))
+	}`)
+	require.Contains(t, string(converter), `	id, err := uuid.Parse(m.Id)
+	if err != nil {
+		return nil, fmt.Errorf("UsersFromProto: invalid id: %w", err)
+	}
+	e.ID = id
+
+	if m.Age != nil {
+		v := int16(m.Age.GetValue())
+		e.Age = &v
+	}`)
+}
+
+func Test_Generator_proto_without_go_package(t *testing.T) {
+	g := &generator.Generator{
+		SchemaLoader: &mockSchemaLoader{},
+		Tables:       []string{"users"},
+		Writer:       &mockWriter{},
+		Proto:        &generator.ProtoOptions{},
+	}
+	require.EqualError(t, g.Generate(), "generator: go_package of proto must not be empty")
+}
+
+func Test_Generator_unknown_tag_style(t *testing.T) {
+	g := &generator.Generator{
+		SchemaLoader: &mockSchemaLoader{},
+		Tables:       []string{"users"},
+		Writer:       &mockWriter{},
+		Tags: []generator.Tag{
+			{Key: "json", Style: "kebab"},
+		},
+	}
+	require.EqualError(t, g.Generate(), "generator: tag json has unknown style kebab")
+}
+
 func Test_Generator_table_not_found(t *testing.T) {
 	loader := &mockSchemaLoader{
 		Schema: &generator.Schema{
@@ -104,7 +501,7 @@ func Test_Generator_table_not_found(t *testing.T) {
 	writer := &mockWriter{}
 	g := &generator.Generator{
 		SchemaLoader: loader,
-		Table:        "mock_table_not_found",
+		Tables:       []string{"mock_table_not_found"},
 		Writer:       writer,
 	}
 	require.EqualError(t, g.Generate(), "generator: mock_table_not_found couldn't be found in the schema")
@@ -117,8 +514,145 @@ func Test_Generator_schema_error(t *testing.T) {
 	writer := &mockWriter{}
 	g := &generator.Generator{
 		SchemaLoader: loader,
-		Table:        "mock_table",
+		Tables:       []string{"mock_table"},
 		Writer:       writer,
 	}
 	require.EqualError(t, g.Generate(), "random error")
 }
+
+func Test_Generator_queries(t *testing.T) {
+	loader := &mockSchemaLoader{
+		Schema: &generator.Schema{
+			Queries: []*generator.Query{
+				{
+					Name:    "RenameUser",
+					Command: generator.QueryExec,
+					File:    "users",
+					SQL:     "UPDATE users SET name = $1 WHERE id = $2",
+					Params: []*generator.Column{
+						{Name: "name", DataType: "text"},
+						{Name: "id", DataType: "uuid"},
+					},
+				},
+			},
+		},
+	}
+	writer := &mockWriter{}
+	g := &generator.Generator{
+		SchemaLoader: loader,
+		Writer:       writer,
+	}
+	require.NoError(t, g.Generate())
+	require.Equal(t, `users.sql.pggo.go
+package model
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/bongnv/pggo/pkg/sqlb"
+)
+
+const renameUserSQL = `+"`UPDATE users SET name = $1 WHERE id = $2`"+`
+
+// RenameUserParams contains parameters of RenameUser.
+type RenameUserParams struct {
+	Name string
+	ID   uuid.UUID
+}
+
+// RenameUser runs the RenameUser query.
+func RenameUser(ctx context.Context, db sqlb.DB, params RenameUserParams) error {
+	return db.Exec(ctx, renameUserSQL, []interface{}{params.Name, params.ID}, nil)
+}
+`,
+		writer.String())
+}
+
+type mapCache map[string]string
+
+func (c mapCache) Hash(table string) string {
+	return c[table]
+}
+
+func (c mapCache) SetHash(table, hash string) {
+	c[table] = hash
+}
+
+func manyTablesSchema(n int) *generator.Schema {
+	schema := &generator.Schema{
+		Tables: map[string]*generator.Table{},
+	}
+	for i := 0; i < n; i++ {
+		name := fmt.Sprintf("table_%03d", i)
+		schema.Tables[name] = &generator.Table{
+			Name: name,
+			Columns: []*generator.Column{
+				{Name: "id", DataType: "bigint"},
+			},
+		}
+	}
+
+	return schema
+}
+
+func Test_Generator_all_tables(t *testing.T) {
+	w := &fileNameWriter{}
+	g := &generator.Generator{
+		SchemaLoader: &mockSchemaLoader{Schema: manyTablesSchema(50)},
+		All:          true,
+		Writer:       w,
+		Workers:      4,
+	}
+	require.NoError(t, g.Generate())
+
+	// files are written in the order of tables regardless of workers
+	var expected []string
+	for i := 0; i < 50; i++ {
+		expected = append(expected, fmt.Sprintf("table_%03d.pggo.go", i), fmt.Sprintf("schema/table_%03d.pggo.go", i))
+	}
+	expected = append(expected, "table_definitions.pggo.go")
+	require.Equal(t, expected, w.files)
+}
+
+func Test_Generator_cache(t *testing.T) {
+	schema := manyTablesSchema(3)
+	cache := mapCache{}
+	w := &fileNameWriter{}
+	g := &generator.Generator{
+		SchemaLoader: &mockSchemaLoader{Schema: schema},
+		All:          true,
+		Writer:       w,
+		Cache:        cache,
+	}
+	require.NoError(t, g.Generate())
+	require.Len(t, w.files, 7)
+	require.Len(t, cache, 3)
+
+	w.files = nil
+	schema.Tables["table_001"].Columns[0].Nullable = true
+	require.NoError(t, g.Generate())
+	// definitions of all tables are listed even though only table_001 is regenerated
+	require.Equal(t, []string{"table_001.pggo.go", "schema/table_001.pggo.go", "table_definitions.pggo.go"}, w.files)
+
+	// changing options regenerates all tables
+	w.files = nil
+	g.Repositories = true
+	require.NoError(t, g.Generate())
+	require.Len(t, w.files, 13)
+
+	// so does writing files into another directory
+	w.files = nil
+	g.OutputDir = "/tmp/model"
+	require.NoError(t, g.Generate())
+	require.Len(t, w.files, 13)
+}
+
+func Test_Generator_no_tables(t *testing.T) {
+	g := &generator.Generator{
+		SchemaLoader: &mockSchemaLoader{Schema: &generator.Schema{}},
+		Writer:       &mockWriter{},
+	}
+	require.EqualError(t, g.Generate(), "generator: there is no table or query to generate code")
+}
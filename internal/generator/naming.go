@@ -0,0 +1,150 @@
+package generator
+
+import (
+	"go/token"
+	"strings"
+	"unicode"
+)
+
+// commonInitialisms is a set of common initialisms which are written in upper case in Go identifiers.
+var commonInitialisms = map[string]bool{
+	"ACL":   true,
+	"API":   true,
+	"ASCII": true,
+	"CPU":   true,
+	"CSS":   true,
+	"DNS":   true,
+	"EOF":   true,
+	"GUID":  true,
+	"HTML":  true,
+	"HTTP":  true,
+	"HTTPS": true,
+	"ID":    true,
+	"IP":    true,
+	"JSON":  true,
+	"RAM":   true,
+	"SQL":   true,
+	"SSH":   true,
+	"TCP":   true,
+	"TLS":   true,
+	"TTL":   true,
+	"UDP":   true,
+	"UI":    true,
+	"URI":   true,
+	"URL":   true,
+	"UTF8":  true,
+	"UUID":  true,
+	"XML":   true,
+}
+
+// toGoName converts a name in snake case into an exported Go identifier.
+func toGoName(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	sb := &strings.Builder{}
+	for _, w := range words {
+		upper := strings.ToUpper(w)
+		if commonInitialisms[upper] {
+			_, _ = sb.WriteString(upper)
+			continue
+		}
+
+		runes := []rune(w)
+		runes[0] = unicode.ToUpper(runes[0])
+		_, _ = sb.WriteString(string(runes))
+	}
+
+	goName := sb.String()
+	if goName == "" || unicode.IsDigit([]rune(goName)[0]) {
+		goName = "X" + goName
+	}
+
+	return goName
+}
+
+// toLowerGoName converts a name in snake case into an unexported Go identifier.
+func toLowerGoName(name string) string {
+	goName := toGoName(name)
+	runes := []rune(goName)
+
+	// lower the leading upper case letters, e.g. ID -> id, URLPath -> urlPath.
+	i := 0
+	for i < len(runes) && unicode.IsUpper(runes[i]) {
+		i++
+	}
+	if i > 1 && i < len(runes) {
+		i--
+	}
+
+	return strings.ToLower(string(runes[:i])) + string(runes[i:])
+}
+
+// toSnakeCase converts a name into snake case, e.g. CreatedAt -> created_at.
+func toSnakeCase(name string) string {
+	runes := []rune(name)
+	sb := &strings.Builder{}
+	for i, r := range runes {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			_ = sb.WriteByte('_')
+		case unicode.IsUpper(r):
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))) {
+				_ = sb.WriteByte('_')
+			}
+			_, _ = sb.WriteRune(unicode.ToLower(r))
+		default:
+			_, _ = sb.WriteRune(r)
+		}
+	}
+
+	return sb.String()
+}
+
+// toCamelCase converts a name in snake case into camel case, e.g. created_at -> createdAt.
+// Unlike Go identifiers, initialisms aren't upper-cased.
+func toCamelCase(name string) string {
+	words := strings.FieldsFunc(toSnakeCase(name), func(r rune) bool {
+		return r == '_'
+	})
+
+	sb := &strings.Builder{}
+	for i, w := range words {
+		if i == 0 {
+			_, _ = sb.WriteString(w)
+			continue
+		}
+
+		runes := []rune(w)
+		runes[0] = unicode.ToUpper(runes[0])
+		_, _ = sb.WriteString(string(runes))
+	}
+
+	return sb.String()
+}
+
+// reservedVarNames are identifiers used by generated code which must not be shadowed by variables.
+var reservedVarNames = map[string]bool{
+	"context": true,
+	"ctx":     true,
+	"e":       true,
+	"err":     true,
+	"fmt":     true,
+	"i":       true,
+	"r":       true,
+	"row":     true,
+	"rows":    true,
+	"sqlb":    true,
+	"sync":    true,
+}
+
+// toVarName converts a name in snake case into a Go identifier for local variables.
+func toVarName(name string) string {
+	varName := toLowerGoName(name)
+	if token.IsKeyword(varName) || reservedVarNames[varName] {
+		return varName + "Value"
+	}
+
+	return varName
+}
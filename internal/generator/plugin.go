@@ -0,0 +1,108 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/exec"
+	"path"
+	"strings"
+)
+
+// PluginProtocolVersion is the version of the protocol between pggo and plugins.
+const PluginProtocolVersion = 1
+
+// PluginRequest is written as JSON to the stdin of a plugin.
+type PluginRequest struct {
+	Version int               `json:"version"`
+	Schema  *Schema           `json:"schema"`
+	Options map[string]string `json:"options,omitempty"`
+}
+
+// PluginFile is a file generated by a plugin.
+type PluginFile struct {
+	// Name is the path of the file relative to the output directory.
+	Name    string `json:"name"`
+	Content string `json:"content"`
+}
+
+// PluginResponse is read as JSON from the stdout of a plugin.
+type PluginResponse struct {
+	Files []PluginFile `json:"files"`
+	// Error reports a failure of the plugin, no file is written if it's not empty.
+	Error string `json:"error,omitempty"`
+}
+
+// PluginGenerator runs an external executable to generate files from DB schema.
+// The plugin receives a PluginRequest via stdin and replies a PluginResponse via stdout.
+// Messages written to stderr are passed through.
+type PluginGenerator struct {
+	SchemaLoader SchemaLoader
+	Writer       Writer
+	// Command is the executable of the plugin, it's looked up in PATH if it doesn't contain a path separator.
+	Command string
+	Args    []string
+	Options map[string]string
+	// Stderr receives messages written to stderr by the plugin.
+	Stderr io.Writer
+}
+
+// Generate runs the plugin and writes the files it generates.
+func (g *PluginGenerator) Generate() error {
+	schema, err := g.SchemaLoader.Load()
+	if err != nil {
+		return err
+	}
+
+	req, err := json.Marshal(&PluginRequest{
+		Version: PluginProtocolVersion,
+		Schema:  schema,
+		Options: g.Options,
+	})
+	if err != nil {
+		return err
+	}
+
+	stdout := &bytes.Buffer{}
+	cmd := exec.Command(g.Command, g.Args...)
+	cmd.Stdin = bytes.NewReader(req)
+	cmd.Stdout = stdout
+	cmd.Stderr = g.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("generator: plugin %s failed: %w", g.Command, err)
+	}
+
+	resp := &PluginResponse{}
+	if err := json.Unmarshal(stdout.Bytes(), resp); err != nil {
+		return fmt.Errorf("generator: plugin %s returned an invalid response: %w", g.Command, err)
+	}
+
+	if resp.Error != "" {
+		return fmt.Errorf("generator: plugin %s: %s", g.Command, resp.Error)
+	}
+
+	for _, f := range resp.Files {
+		if err := validatePluginFileName(f.Name); err != nil {
+			return fmt.Errorf("generator: plugin %s: %w", g.Command, err)
+		}
+	}
+
+	for _, f := range resp.Files {
+		if err := g.Writer.Write(f.Name, []byte(f.Content)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validatePluginFileName ensures a plugin can only write files inside the output directory.
+func validatePluginFileName(name string) error {
+	cleaned := path.Clean(strings.ReplaceAll(name, "\\", "/"))
+	if name == "" || path.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return fmt.Errorf("invalid file name %q", name)
+	}
+
+	return nil
+}
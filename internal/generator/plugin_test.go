@@ -0,0 +1,96 @@
+package generator_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bongnv/pggo/internal/generator"
+	"github.com/bongnv/pggo/internal/writer"
+)
+
+// Test_PluginHelper isn't a real test, it acts as a plugin when it's run by PluginGenerator.
+func Test_PluginHelper(t *testing.T) {
+	mode := os.Getenv("PGGO_PLUGIN_HELPER")
+	if mode == "" {
+		return
+	}
+
+	req := &generator.PluginRequest{}
+	if err := json.NewDecoder(os.Stdin).Decode(req); err != nil {
+		os.Exit(2)
+	}
+
+	resp := &generator.PluginResponse{}
+	switch mode {
+	case "error":
+		resp.Error = "unsupported schema"
+	case "invalid":
+		resp.Files = []generator.PluginFile{{Name: "../outside.txt"}}
+	case "crash":
+		os.Exit(1)
+	default:
+		for _, table := range req.Schema.SortedTables() {
+			resp.Files = append(resp.Files, generator.PluginFile{
+				Name:    "graphql/" + table.Name + ".graphql",
+				Content: "# " + table.Name + " v" + req.Options["version"] + "\n",
+			})
+		}
+	}
+
+	_ = json.NewEncoder(os.Stdout).Encode(resp)
+	os.Exit(0)
+}
+
+func newPluginGenerator(t *testing.T, mode string, w generator.Writer) *generator.PluginGenerator {
+	t.Setenv("PGGO_PLUGIN_HELPER", mode)
+	return &generator.PluginGenerator{
+		SchemaLoader: &mockSchemaLoader{Schema: docsSchema()},
+		Writer:       w,
+		Command:      os.Args[0],
+		Args:         []string{"-test.run=^Test_PluginHelper$"},
+		Options:      map[string]string{"version": "2"},
+	}
+}
+
+func Test_PluginGenerator(t *testing.T) {
+	w := writer.NewMemoryWriter()
+	require.NoError(t, newPluginGenerator(t, "ok", w).Generate())
+
+	content, err := fs.ReadFile(w.FS, "graphql/orgs.graphql")
+	require.NoError(t, err)
+	require.Equal(t, "# orgs v2\n", string(content))
+
+	content, err = fs.ReadFile(w.FS, "graphql/users.graphql")
+	require.NoError(t, err)
+	require.Equal(t, "# users v2\n", string(content))
+}
+
+func Test_PluginGenerator_errors(t *testing.T) {
+	t.Run("error response", func(t *testing.T) {
+		g := newPluginGenerator(t, "error", writer.NewMemoryWriter())
+		require.EqualError(t, g.Generate(), "generator: plugin "+os.Args[0]+": unsupported schema")
+	})
+
+	t.Run("file outside of the output directory", func(t *testing.T) {
+		w := writer.NewMemoryWriter()
+		g := newPluginGenerator(t, "invalid", w)
+		require.EqualError(t, g.Generate(), "generator: plugin "+os.Args[0]+`: invalid file name "../outside.txt"`)
+		require.Empty(t, w.FS)
+	})
+
+	t.Run("plugin exits with error", func(t *testing.T) {
+		g := newPluginGenerator(t, "crash", writer.NewMemoryWriter())
+		require.EqualError(t, g.Generate(), "generator: plugin "+os.Args[0]+" failed: exit status 1")
+	})
+
+	t.Run("schema error", func(t *testing.T) {
+		g := newPluginGenerator(t, "ok", writer.NewMemoryWriter())
+		g.SchemaLoader = &mockSchemaLoader{Err: errors.New("random error")}
+		require.EqualError(t, g.Generate(), "random error")
+	})
+}
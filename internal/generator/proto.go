@@ -0,0 +1,294 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ProtoOptions configures generating protobuf messages and converters of tables.
+type ProtoOptions struct {
+	// Package is the package of .proto files, it's model by default.
+	Package string `yaml:"package"`
+	// GoPackage is the go_package option of .proto files, i.e. the import path of types generated by protoc-gen-go.
+	// It's required as converters refer to the generated types.
+	GoPackage string `yaml:"go_package"`
+}
+
+func (o *ProtoOptions) validate() error {
+	if o == nil {
+		return nil
+	}
+
+	if o.GoPackage == "" {
+		return errors.New("generator: go_package of proto must not be empty")
+	}
+
+	return nil
+}
+
+// PackageName returns the package of .proto files.
+func (o *ProtoOptions) PackageName() string {
+	if o.Package == "" {
+		return "model"
+	}
+
+	return o.Package
+}
+
+// GoImportPath returns the import path of types generated by protoc-gen-go, e.g. "example.com/pb" of "example.com/pb;pb".
+func (o *ProtoOptions) GoImportPath() string {
+	return strings.SplitN(o.GoPackage, ";", 2)[0]
+}
+
+const (
+	protoTimestamp = "google.protobuf.Timestamp"
+	protoDuration  = "google.protobuf.Duration"
+)
+
+// protoScalar describes how values of a data type are mapped to protobuf.
+type protoScalar struct {
+	// Type is the protobuf type.
+	Type string
+	// Wrapper is the name of the wrapper of nullable values in wrapperspb, e.g. Int32. It's empty for messages.
+	Wrapper string
+	// To and From are formats converting a Go value to protobuf and back.
+	To   string
+	From string
+	// Parse reports whether From is a function returning a value and an error.
+	Parse bool
+}
+
+var protoScalars = map[string]protoScalar{
+	"smallint":                    {Type: "int32", Wrapper: "Int32", To: "int32(%s)", From: "int16(%s)"},
+	"integer":                     {Type: "int32", Wrapper: "Int32", To: "%s", From: "%s"},
+	"bigint":                      {Type: "int64", Wrapper: "Int64", To: "%s", From: "%s"},
+	"real":                        {Type: "float", Wrapper: "Float", To: "%s", From: "%s"},
+	"double precision":            {Type: "double", Wrapper: "Double", To: "%s", From: "%s"},
+	"numeric":                     {Type: "double", Wrapper: "Double", To: "%s", From: "%s"},
+	"boolean":                     {Type: "bool", Wrapper: "Bool", To: "%s", From: "%s"},
+	"text":                        {Type: "string", Wrapper: "String", To: "%s", From: "%s"},
+	"character varying":           {Type: "string", Wrapper: "String", To: "%s", From: "%s"},
+	"character":                   {Type: "string", Wrapper: "String", To: "%s", From: "%s"},
+	"citext":                      {Type: "string", Wrapper: "String", To: "%s", From: "%s"},
+	"bytea":                       {Type: "bytes", Wrapper: "Bytes", To: "%s", From: "%s"},
+	"uuid":                        {Type: "string", Wrapper: "String", To: "%s.String()", From: "uuid.Parse(%s)", Parse: true},
+	"json":                        {Type: "string", Wrapper: "String", To: "string(%s)", From: "json.RawMessage(%s)"},
+	"jsonb":                       {Type: "string", Wrapper: "String", To: "string(%s)", From: "json.RawMessage(%s)"},
+	"date":                        {Type: protoTimestamp, To: "timestamppb.New(%s)", From: "%s.AsTime()"},
+	"timestamp without time zone": {Type: protoTimestamp, To: "timestamppb.New(%s)", From: "%s.AsTime()"},
+	"timestamp with time zone":    {Type: protoTimestamp, To: "timestamppb.New(%s)", From: "%s.AsTime()"},
+	"interval":                    {Type: protoDuration, To: "durationpb.New(%s)", From: "%s.AsDuration()"},
+}
+
+// protoEnum maps enums to strings as values of enums can be added or reordered.
+var protoEnum = protoScalar{Type: "string", Wrapper: "String", To: "fmt.Sprint(%s)", From: "%s"}
+
+// protoRepeated contains element types of arrays that protobuf and Go share, so values are assigned directly.
+var protoRepeated = map[string]string{
+	"integer":           "int32",
+	"bigint":            "int64",
+	"real":              "float",
+	"double precision":  "double",
+	"numeric":           "double",
+	"boolean":           "bool",
+	"text":              "string",
+	"character varying": "string",
+	"citext":            "string",
+}
+
+// ProtoField represents a field of a protobuf message mapped from a column.
+type ProtoField struct {
+	Column *Column
+	// Number is the position of the column, so numbers are stable as long as columns are only appended.
+	Number int
+	// Type is the protobuf type, it's empty if the data type isn't supported.
+	Type   string
+	scalar protoScalar
+}
+
+// Name returns the name of the protobuf field.
+func (f *ProtoField) Name() string {
+	return f.Column.Name
+}
+
+// GoName returns the name of the Go field generated by protoc-gen-go.
+func (f *ProtoField) GoName() string {
+	return protoGoName(f.Column.Name)
+}
+
+// Nullable reports whether NULL is kept in protobuf, values are checked against nil then.
+// NULL arrays are converted to empty repeated fields.
+func (f *ProtoField) Nullable() bool {
+	return f.Column.Nullable && !strings.HasPrefix(f.Type, "repeated ")
+}
+
+// Parse reports whether converting from protobuf can fail.
+func (f *ProtoField) Parse() bool {
+	return f.scalar.Parse
+}
+
+// IsPointer reports whether the model field is a pointer.
+func (f *ProtoField) IsPointer() bool {
+	return strings.HasPrefix(f.Column.GoType(), "*")
+}
+
+// ToProto returns the expression converting the model field of e into the protobuf value.
+func (f *ProtoField) ToProto() string {
+	value := "e." + f.Column.GoName()
+	// methods can be called on pointers directly
+	if f.IsPointer() && !strings.HasPrefix(f.scalar.To, "%s.") {
+		value = "*" + value
+	}
+
+	expr := fmt.Sprintf(f.scalar.To, value)
+	if f.Nullable() && f.scalar.Wrapper != "" {
+		expr = "wrapperspb." + f.scalar.Wrapper + "(" + expr + ")"
+	}
+
+	return expr
+}
+
+// FromProto returns the expression converting the protobuf field of m into the model value.
+func (f *ProtoField) FromProto() string {
+	value := "m." + f.GoName()
+	if f.Nullable() && f.scalar.Wrapper != "" {
+		value += ".GetValue()"
+	}
+
+	return fmt.Sprintf(f.scalar.From, value)
+}
+
+type protoData struct {
+	PackageName  string
+	StdImports   []string
+	Imports      []string
+	ProtoImports []string
+	Table        *Table
+	Options      *ProtoOptions
+	Fields       []*ProtoField
+	// Reserved contains field numbers of dropped columns.
+	Reserved []string
+}
+
+// newProtoData maps columns of a table into protobuf fields. Columns of unsupported types have fields without types.
+func newProtoData(schema *Schema, t *Table, options *ProtoOptions) *protoData {
+	data := &protoData{
+		PackageName: "model",
+		Table:       t,
+		Options:     options,
+	}
+
+	paths := []string{}
+	protoImports := map[string]bool{}
+	used := map[int]bool{}
+	maxNumber := 0
+	for i, c := range t.Columns {
+		// positions stay the same when other columns are dropped, so field numbers remain compatible
+		f := &ProtoField{
+			Column: c,
+			Number: c.Position,
+		}
+		if f.Number == 0 {
+			f.Number = i + 1
+		}
+
+		data.Fields = append(data.Fields, f)
+		used[f.Number] = true
+		if f.Number > maxNumber {
+			maxNumber = f.Number
+		}
+
+		switch {
+		case schema.Enums[c.DataType] != nil:
+			f.scalar = protoEnum
+			paths = append(paths, "fmt")
+		case strings.HasSuffix(c.DataType, "[]"):
+			elem, ok := protoRepeated[strings.TrimSuffix(c.DataType, "[]")]
+			if !ok {
+				continue
+			}
+
+			f.scalar = protoScalar{Type: "repeated " + elem, To: "%s", From: "%s"}
+		default:
+			scalar, ok := protoScalars[c.DataType]
+			if !ok {
+				continue
+			}
+
+			f.scalar = scalar
+		}
+
+		f.Type = f.scalar.Type
+		switch {
+		case f.Type == protoTimestamp:
+			protoImports["google/protobuf/timestamp.proto"] = true
+			paths = append(paths, "google.golang.org/protobuf/types/known/timestamppb")
+		case f.Type == protoDuration:
+			protoImports["google/protobuf/duration.proto"] = true
+			paths = append(paths, "google.golang.org/protobuf/types/known/durationpb")
+		case f.Nullable():
+			f.Type = "google.protobuf." + f.scalar.Wrapper + "Value"
+			protoImports["google/protobuf/wrappers.proto"] = true
+			paths = append(paths, "google.golang.org/protobuf/types/known/wrapperspb")
+		}
+
+		switch c.DataType {
+		case "uuid":
+			paths = append(paths, "fmt", "github.com/google/uuid")
+		case "json", "jsonb":
+			paths = append(paths, "encoding/json")
+		}
+	}
+
+	for n := 1; n < maxNumber; n++ {
+		if !used[n] {
+			data.Reserved = append(data.Reserved, strconv.Itoa(n))
+		}
+	}
+
+	for _, p := range []string{"google/protobuf/duration.proto", "google/protobuf/timestamp.proto", "google/protobuf/wrappers.proto"} {
+		if protoImports[p] {
+			data.ProtoImports = append(data.ProtoImports, p)
+		}
+	}
+
+	data.StdImports, data.Imports = groupImports(paths)
+	return data
+}
+
+// protoGoName converts the name of a protobuf field into the Go name generated by protoc-gen-go, e.g. user_id to UserId.
+func protoGoName(name string) string {
+	sb := &strings.Builder{}
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case c == '_' && i == 0:
+			_ = sb.WriteByte('X')
+		case c == '_' && i+1 < len(name) && isASCIILower(name[i+1]):
+			// skip the underscore as the next letter is capitalised
+		case isASCIIDigit(c):
+			_ = sb.WriteByte(c)
+		default:
+			if isASCIILower(c) {
+				c -= 'a' - 'A'
+			}
+			_ = sb.WriteByte(c)
+
+			for ; i+1 < len(name) && isASCIILower(name[i+1]); i++ {
+				_ = sb.WriteByte(name[i+1])
+			}
+		}
+	}
+
+	return sb.String()
+}
+
+func isASCIILower(c byte) bool {
+	return 'a' <= c && c <= 'z'
+}
+
+func isASCIIDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
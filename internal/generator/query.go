@@ -0,0 +1,30 @@
+package generator
+
+import (
+	"strconv"
+	"strings"
+)
+
+// GoName returns the name of the Go function running the query.
+func (q *Query) GoName() string {
+	return toGoName(q.Name)
+}
+
+// VarName returns the unexported Go identifier of the query.
+func (q *Query) VarName() string {
+	return toLowerGoName(q.Name)
+}
+
+// SQLLiteral returns the SQL of the query as a Go string literal.
+func (q *Query) SQLLiteral() string {
+	if strings.Contains(q.SQL, "`") {
+		return strconv.Quote(q.SQL)
+	}
+
+	return "`" + q.SQL + "`"
+}
+
+// ReturnsRows reports whether the query returns rows.
+func (q *Query) ReturnsRows() bool {
+	return q.Command == QueryOne || q.Command == QueryMany
+}
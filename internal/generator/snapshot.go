@@ -0,0 +1,33 @@
+package generator
+
+import "encoding/json"
+
+// DefaultSnapshotFileName is the name of the snapshot file if FileName isn't set.
+const DefaultSnapshotFileName = "schema.json"
+
+// SnapshotGenerator writes DB schema into a JSON file, so that it can be loaded later without a DB.
+type SnapshotGenerator struct {
+	SchemaLoader SchemaLoader
+	Writer       Writer
+	FileName     string
+}
+
+// Generate writes the snapshot of the schema.
+func (g *SnapshotGenerator) Generate() error {
+	schema, err := g.SchemaLoader.Load()
+	if err != nil {
+		return err
+	}
+
+	content, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fileName := g.FileName
+	if fileName == "" {
+		fileName = DefaultSnapshotFileName
+	}
+
+	return g.Writer.Write(fileName, append(content, '\n'))
+}
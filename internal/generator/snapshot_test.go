@@ -0,0 +1,39 @@
+package generator_test
+
+import (
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bongnv/pggo/internal/generator"
+	"github.com/bongnv/pggo/internal/writer"
+)
+
+func Test_SnapshotGenerator(t *testing.T) {
+	w := writer.NewMemoryWriter()
+	g := &generator.SnapshotGenerator{
+		SchemaLoader: &mockSchemaLoader{
+			Schema: &generator.Schema{
+				Tables: map[string]*generator.Table{
+					"users": {
+						Name:    "users",
+						Columns: []*generator.Column{{Name: "id", DataType: "bigint"}},
+					},
+				},
+				Enums: map[string]*generator.Enum{
+					"mood": {Name: "mood", Values: []string{"happy", "sad"}},
+				},
+			},
+		},
+		Writer: w,
+	}
+	require.NoError(t, g.Generate())
+
+	content, err := fs.ReadFile(w.FS, "schema.json")
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+  "tables": {"users": {"name": "users", "columns": [{"name": "id", "data_type": "bigint"}]}},
+  "enums": {"mood": {"name": "mood", "values": ["happy", "sad"]}}
+}`, string(content))
+}
@@ -0,0 +1,217 @@
+package generator
+
+import (
+	"sort"
+
+	"github.com/bongnv/pggo/pkg/sqlb"
+)
+
+// GoName returns the name of the Go type representing the table.
+func (t *Table) GoName() string {
+	return toGoName(t.Name)
+}
+
+// VarName returns the unexported Go identifier of the table.
+func (t *Table) VarName() string {
+	return toLowerGoName(t.Name)
+}
+
+// Column returns a column given its name. It returns nil if the column doesn't exist.
+func (t *Table) Column(name string) *Column {
+	for _, c := range t.Columns {
+		if c.Name == name {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// PrimaryKeyColumns returns columns of the primary key.
+func (t *Table) PrimaryKeyColumns() []*Column {
+	if t.PrimaryKey == nil {
+		return nil
+	}
+
+	return t.constraintColumns(t.PrimaryKey)
+}
+
+// PartitionKeyColumns returns columns of the partition key, it's empty if the table isn't partitioned.
+func (t *Table) PartitionKeyColumns() []*Column {
+	if t.PartitionKey == nil {
+		return nil
+	}
+
+	var cols []*Column
+	for _, name := range t.PartitionKey.Columns {
+		if col := t.Column(name); col != nil {
+			cols = append(cols, col)
+		}
+	}
+
+	return cols
+}
+
+// NonPrimaryKeyColumns returns columns which aren't part of the primary key.
+func (t *Table) NonPrimaryKeyColumns() []*Column {
+	keys := map[string]bool{}
+	if t.PrimaryKey != nil {
+		for _, name := range t.PrimaryKey.Columns {
+			keys[name] = true
+		}
+	}
+
+	var cols []*Column
+	for _, c := range t.Columns {
+		if !keys[c.Name] {
+			cols = append(cols, c)
+		}
+	}
+
+	return cols
+}
+
+// DefaultColumns returns columns with defaults or identities, which are left to the database if they're empty.
+func (t *Table) DefaultColumns() []*Column {
+	var cols []*Column
+	for _, c := range t.Columns {
+		if c.HasDefault() {
+			cols = append(cols, c)
+		}
+	}
+
+	return cols
+}
+
+func (t *Table) constraintColumns(c *Constraint) []*Column {
+	cols := make([]*Column, 0, len(c.Columns))
+	for _, name := range c.Columns {
+		if col := t.Column(name); col != nil {
+			cols = append(cols, col)
+		}
+	}
+
+	return cols
+}
+
+// ColumnsOf returns columns of the constraint in the given table.
+func (c *Constraint) ColumnsOf(t *Table) []*Column {
+	return t.constraintColumns(c)
+}
+
+// ColumnKeys returns abbreviations of keys containing the column: PK, UK and FK.
+func (t *Table) ColumnKeys(name string) []string {
+	var keys []string
+	if t.PrimaryKey != nil && containsString(t.PrimaryKey.Columns, name) {
+		keys = append(keys, "PK")
+	}
+
+	for _, u := range t.UniqueKeys {
+		if containsString(u.Columns, name) {
+			keys = append(keys, "UK")
+			break
+		}
+	}
+
+	for _, fk := range t.ForeignKeys {
+		if containsString(fk.Columns, name) {
+			keys = append(keys, "FK")
+			break
+		}
+	}
+
+	return keys
+}
+
+// SortedTables returns tables of the schema sorted by names.
+func (s *Schema) SortedTables() []*Table {
+	tables := make([]*Table, 0, len(s.Tables))
+	for _, t := range s.Tables {
+		tables = append(tables, t)
+	}
+
+	sort.Slice(tables, func(i, j int) bool {
+		return tables[i].Name < tables[j].Name
+	})
+
+	return tables
+}
+
+// enumsOf returns enums used by columns of a table.
+func (s *Schema) enumsOf(t *Table) []*Enum {
+	var enums []*Enum
+	for _, c := range t.Columns {
+		if e := s.Enums[c.DataType]; e != nil {
+			enums = append(enums, e)
+		}
+	}
+
+	return enums
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsOptional reports whether a foreign key of the table contains nullable columns, i.e. the reference is optional.
+func (t *Table) IsOptional(fk *ForeignKey) bool {
+	for _, name := range fk.Columns {
+		if c := t.Column(name); c != nil && c.Nullable {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Identifiers returns package level Go identifiers generated for the table.
+func (t *Table) Identifiers() []string {
+	name, varName := t.GoName(), t.VarName()
+	return []string{
+		name,
+		name + "List",
+		name + "Definition",
+		name + "Repository",
+		"New" + name + "Repository",
+		"InMemory" + name + "Repository",
+		"NewInMemory" + name + "Repository",
+		name + "Factory",
+		"New" + name + "Factory",
+		name + "ToProto",
+		name + "FromProto",
+		varName + "Columns",
+		varName + "UpdateSQL",
+		varName + "DeleteSQL",
+		varName + "Repository",
+		varName + "Inserted",
+		varName + "PrimaryKey",
+		varName + "UniqueKey",
+		varName + "UniqueKeys",
+		varName + "Clone",
+	}
+}
+
+// ColumnsFingerprint returns the hash of columns used by sqlb.VerifySchema to detect schema drift.
+func (t *Table) ColumnsFingerprint() string {
+	cols := make([]sqlb.ColumnDefinition, len(t.Columns))
+	for i, c := range t.Columns {
+		cols[i] = sqlb.ColumnDefinition{
+			Name:     c.Name,
+			DataType: c.DataType,
+			Nullable: c.Nullable,
+		}
+	}
+
+	return sqlb.ColumnsFingerprint(cols)
+}
+
+// Fingerprint returns a hash of the table definition, it changes whenever the table is altered.
+func (t *Table) Fingerprint() string {
+	return fingerprint(t)
+}
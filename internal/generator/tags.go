@@ -0,0 +1,87 @@
+package generator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Naming styles for values of struct tags.
+const (
+	// StyleAsIs keeps column names as they are.
+	StyleAsIs = "as-is"
+	// StyleSnake converts column names into snake case, e.g. created_at.
+	StyleSnake = "snake"
+	// StyleCamel converts column names into camel case, e.g. createdAt.
+	StyleCamel = "camel"
+)
+
+// Tag defines a struct tag generated for fields of models.
+type Tag struct {
+	// Key is the key of the tag, e.g. json, db or yaml.
+	Key string `yaml:"key"`
+	// Style is the naming style of the tag value. It's as-is by default.
+	Style string `yaml:"style"`
+	// OmitEmpty adds omitempty to the tag of nullable columns.
+	OmitEmpty bool `yaml:"omitempty"`
+}
+
+// ColumnOverride customises code generated for a column.
+type ColumnOverride struct {
+	// Tags overrides values of struct tags by keys, e.g. "-" to hide a field from JSON.
+	Tags map[string]string `yaml:"tags"`
+}
+
+func validateTags(tags []Tag) error {
+	for _, tag := range tags {
+		if tag.Key == "" {
+			return fmt.Errorf("generator: tag key must not be empty")
+		}
+
+		switch tag.Style {
+		case "", StyleAsIs, StyleSnake, StyleCamel:
+		default:
+			return fmt.Errorf("generator: tag %s has unknown style %s", tag.Key, tag.Style)
+		}
+	}
+
+	return nil
+}
+
+// tagValue returns the value of a struct tag for a column.
+func tagValue(tag Tag, override ColumnOverride, c *Column) string {
+	if v, ok := override.Tags[tag.Key]; ok {
+		return v
+	}
+
+	var value string
+	switch tag.Style {
+	case StyleSnake:
+		value = toSnakeCase(c.Name)
+	case StyleCamel:
+		value = toCamelCase(c.Name)
+	default:
+		value = c.Name
+	}
+
+	if tag.OmitEmpty && c.Nullable {
+		value += ",omitempty"
+	}
+
+	return value
+}
+
+// structTag returns the struct tag of a column including the backquotes.
+// It returns an empty string if there is no tag.
+func structTag(tags []Tag, override ColumnOverride, c *Column) string {
+	if len(tags) == 0 {
+		return ""
+	}
+
+	parts := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		parts = append(parts, tag.Key+":"+strconv.Quote(tagValue(tag, override, c)))
+	}
+
+	return "`" + strings.Join(parts, " ") + "`"
+}
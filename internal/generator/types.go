@@ -0,0 +1,130 @@
+package generator
+
+import (
+	"sort"
+	"strings"
+)
+
+type goType struct {
+	name       string
+	importPath string
+}
+
+// goTypes maps PostgreSQL data types to Go types.
+var goTypes = map[string]goType{
+	"smallint":                    {name: "int16"},
+	"integer":                     {name: "int32"},
+	"bigint":                      {name: "int64"},
+	"real":                        {name: "float32"},
+	"double precision":            {name: "float64"},
+	"numeric":                     {name: "float64"},
+	"boolean":                     {name: "bool"},
+	"text":                        {name: "string"},
+	"character varying":           {name: "string"},
+	"character":                   {name: "string"},
+	"citext":                      {name: "string"},
+	"bytea":                       {name: "[]byte"},
+	"uuid":                        {name: "uuid.UUID", importPath: "github.com/google/uuid"},
+	"json":                        {name: "json.RawMessage", importPath: "encoding/json"},
+	"jsonb":                       {name: "json.RawMessage", importPath: "encoding/json"},
+	"date":                        {name: "time.Time", importPath: "time"},
+	"timestamp without time zone": {name: "time.Time", importPath: "time"},
+	"timestamp with time zone":    {name: "time.Time", importPath: "time"},
+	"interval":                    {name: "time.Duration", importPath: "time"},
+}
+
+func lookupGoType(dataType string) goType {
+	if strings.HasSuffix(dataType, "[]") {
+		elem := lookupGoType(strings.TrimSuffix(dataType, "[]"))
+		return goType{
+			name:       "[]" + elem.name,
+			importPath: elem.importPath,
+		}
+	}
+
+	if t, ok := goTypes[dataType]; ok {
+		return t
+	}
+
+	return goType{name: "interface{}"}
+}
+
+// GoName returns the name of the Go field representing the column.
+func (c *Column) GoName() string {
+	return toGoName(c.Name)
+}
+
+// VarName returns the Go identifier for a local variable holding a value of the column.
+func (c *Column) VarName() string {
+	return toVarName(c.Name)
+}
+
+// HasDefault reports whether the database provides values of the column, e.g. serial or identity columns.
+func (c *Column) HasDefault() bool {
+	return c.Default != "" || c.Identity != ""
+}
+
+// GoType returns the Go type for storing values of the column.
+// Nullable columns are stored as pointers unless the type can hold nil already.
+func (c *Column) GoType() string {
+	t := lookupGoType(c.DataType).name
+	if !c.Nullable || strings.HasPrefix(t, "[]") || t == "interface{}" || t == "json.RawMessage" {
+		return t
+	}
+
+	return "*" + t
+}
+
+// KeyValue returns the expression of the column value v in keys of in-memory repositories.
+// Times are converted to UTC as equal instants in different locations are formatted differently.
+func (c *Column) KeyValue(v string) string {
+	switch {
+	case lookupGoType(c.DataType).name == "time.Time":
+		return v + ".UTC()"
+	case strings.HasPrefix(c.GoType(), "*"):
+		return "*" + v
+	default:
+		return v
+	}
+}
+
+// importPaths returns sorted import paths required by the Go types of the given columns.
+func importPaths(cols ...[]*Column) []string {
+	paths := map[string]bool{}
+	for _, list := range cols {
+		for _, c := range list {
+			if p := lookupGoType(c.DataType).importPath; p != "" {
+				paths[p] = true
+			}
+		}
+	}
+
+	result := make([]string, 0, len(paths))
+	for p := range paths {
+		result = append(result, p)
+	}
+	sort.Strings(result)
+
+	return result
+}
+
+// groupImports deduplicates, sorts and splits import paths into standard and third-party packages.
+func groupImports(paths []string) (std []string, others []string) {
+	seen := map[string]bool{}
+	for _, p := range paths {
+		if seen[p] {
+			continue
+		}
+		seen[p] = true
+
+		if strings.Contains(strings.SplitN(p, "/", 2)[0], ".") {
+			others = append(others, p)
+		} else {
+			std = append(std, p)
+		}
+	}
+
+	sort.Strings(std)
+	sort.Strings(others)
+	return std, others
+}
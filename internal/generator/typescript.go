@@ -0,0 +1,200 @@
+package generator
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultTypeScriptFileName is the name of the TypeScript file if FileName isn't set.
+const DefaultTypeScriptFileName = "schema.d.ts"
+
+// TypeScriptGenerator generates TypeScript type definitions with an interface per table and a union type per enum.
+// Interfaces describe JSON encoding of models, so keys, optional fields and null follow JSON tags of the models.
+type TypeScriptGenerator struct {
+	SchemaLoader SchemaLoader
+	Writer       Writer
+	FileName     string
+	// Tags and Overrides are the ones used to generate models.
+	Tags      []Tag
+	Overrides map[string]ColumnOverride
+}
+
+type typeScriptData struct {
+	Enums      []*typeScriptEnum
+	Interfaces []*typeScriptInterface
+}
+
+type typeScriptEnum struct {
+	Name  string
+	Enum  *Enum
+	Union string
+}
+
+type typeScriptInterface struct {
+	Name   string
+	Table  *Table
+	Fields []*typeScriptField
+}
+
+type typeScriptField struct {
+	Key      string
+	Type     string
+	Optional bool
+	Comment  string
+}
+
+// Generate writes type definitions of all tables and enums in the schema.
+func (g *TypeScriptGenerator) Generate() error {
+	if err := validateTags(g.Tags); err != nil {
+		return err
+	}
+
+	schema, err := g.SchemaLoader.Load()
+	if err != nil {
+		return err
+	}
+
+	data := &typeScriptData{}
+	for _, e := range schema.SortedEnums() {
+		values := make([]string, len(e.Values))
+		for i, v := range e.Values {
+			values[i] = strconv.Quote(v)
+		}
+
+		union := strings.Join(values, " | ")
+		if union == "" {
+			union = "never"
+		}
+
+		data.Enums = append(data.Enums, &typeScriptEnum{
+			Name:  toGoName(e.Name),
+			Enum:  e,
+			Union: union,
+		})
+	}
+
+	var jsonTag *Tag
+	for i := range g.Tags {
+		if g.Tags[i].Key == "json" {
+			jsonTag = &g.Tags[i]
+		}
+	}
+
+	for _, t := range schema.SortedTables() {
+		i := &typeScriptInterface{
+			Name:  t.GoName(),
+			Table: t,
+		}
+
+		for _, c := range t.Columns {
+			if f := g.field(schema, jsonTag, t, c); f != nil {
+				i.Fields = append(i.Fields, f)
+			}
+		}
+
+		data.Interfaces = append(data.Interfaces, i)
+	}
+
+	f, err := renderFile(g.fileName(), "typescript.tmpl", data)
+	if err != nil {
+		return err
+	}
+
+	return g.Writer.Write(f.name, f.content)
+}
+
+func (g *TypeScriptGenerator) fileName() string {
+	if g.FileName == "" {
+		return DefaultTypeScriptFileName
+	}
+
+	return g.FileName
+}
+
+// field returns the field of a column the same way encoding/json encodes the model field.
+// It returns nil if the field is skipped by the JSON tag.
+func (g *TypeScriptGenerator) field(schema *Schema, jsonTag *Tag, t *Table, c *Column) *typeScriptField {
+	key := c.GoName()
+	var options []string
+	if jsonTag != nil {
+		value := tagValue(*jsonTag, g.Overrides[t.Name+"."+c.Name], c)
+		if value == "-" {
+			return nil
+		}
+
+		parts := strings.Split(value, ",")
+		if parts[0] != "" {
+			key = parts[0]
+		}
+		options = parts[1:]
+	}
+
+	f := &typeScriptField{
+		Key:     key,
+		Type:    typeScriptType(schema, c.DataType),
+		Comment: c.Comment,
+	}
+
+	if !typeScriptIdentifier.MatchString(key) {
+		f.Key = strconv.Quote(key)
+	}
+
+	if containsString(options, "string") && (f.Type == "number" || f.Type == "boolean") {
+		f.Type = "string"
+	}
+
+	goType := c.GoType()
+	switch {
+	case containsString(options, "omitempty") && isOmittable(goType):
+		f.Optional = true
+	case c.Nullable:
+		f.Type += " | null"
+	}
+
+	return f
+}
+
+var typeScriptIdentifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// isOmittable reports whether omitempty omits zero values of a Go type, it doesn't omit structs and arrays.
+func isOmittable(goType string) bool {
+	return goType != "time.Time" && goType != "uuid.UUID"
+}
+
+// typeScriptType returns the type of JSON values encoded from the Go type of a data type.
+func typeScriptType(schema *Schema, dataType string) string {
+	if strings.HasSuffix(dataType, "[]") {
+		return typeScriptType(schema, strings.TrimSuffix(dataType, "[]")) + "[]"
+	}
+
+	if e := schema.Enums[dataType]; e != nil {
+		return toGoName(e.Name)
+	}
+
+	switch lookupGoType(dataType).name {
+	case "int16", "int32", "int64", "float32", "float64", "time.Duration":
+		return "number"
+	case "bool":
+		return "boolean"
+	case "string", "[]byte", "uuid.UUID", "time.Time":
+		return "string"
+	default:
+		return "unknown"
+	}
+}
+
+// SortedEnums returns enums of the schema sorted by names.
+func (s *Schema) SortedEnums() []*Enum {
+	enums := make([]*Enum, 0, len(s.Enums))
+	for _, e := range s.Enums {
+		enums = append(enums, e)
+	}
+
+	sort.Slice(enums, func(i, j int) bool {
+		return enums[i].Name < enums[j].Name
+	})
+
+	return enums
+}
@@ -0,0 +1,106 @@
+package generator_test
+
+import (
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bongnv/pggo/internal/generator"
+	"github.com/bongnv/pggo/internal/writer"
+)
+
+func Test_TypeScriptGenerator(t *testing.T) {
+	loader := &mockSchemaLoader{
+		Schema: &generator.Schema{
+			Enums: map[string]*generator.Enum{
+				"user_role": {Name: "user_role", Values: []string{"admin", "member"}},
+			},
+			Tables: map[string]*generator.Table{
+				"users": {
+					Name: "users",
+					Columns: []*generator.Column{
+						{Name: "id", DataType: "uuid"},
+						{Name: "email", DataType: "text", Comment: "Email to sign in."},
+						{Name: "role", DataType: "user_role"},
+						{Name: "nick_name", DataType: "text", Nullable: true},
+						{Name: "login_count", DataType: "bigint"},
+						{Name: "roles", DataType: "user_role[]", Nullable: true},
+						{Name: "settings", DataType: "jsonb"},
+						{Name: "created_at", DataType: "timestamp with time zone"},
+						{Name: "password_hash", DataType: "text"},
+					},
+				},
+			},
+		},
+	}
+	w := writer.NewMemoryWriter()
+	g := &generator.TypeScriptGenerator{
+		SchemaLoader: loader,
+		Writer:       w,
+		Tags: []generator.Tag{
+			{Key: "json", Style: generator.StyleCamel, OmitEmpty: true},
+		},
+		Overrides: map[string]generator.ColumnOverride{
+			"users.password_hash": {Tags: map[string]string{"json": "-"}},
+			"users.login_count":   {Tags: map[string]string{"json": "login_count,string"}},
+			"users.roles":         {Tags: map[string]string{"json": "user-roles"}},
+		},
+	}
+	require.NoError(t, g.Generate())
+
+	content, err := fs.ReadFile(w.FS, "schema.d.ts")
+	require.NoError(t, err)
+	require.Equal(t, `/** UserRole represents values of user_role enum. */
+export type UserRole = "admin" | "member";
+
+/** Users represents a row of users table. */
+export interface Users {
+  id: string;
+  /** Email to sign in. */
+  email: string;
+  role: UserRole;
+  nickName?: string;
+  login_count: string;
+  "user-roles": UserRole[] | null;
+  settings: unknown;
+  createdAt: string;
+}
+`, string(content))
+}
+
+func Test_TypeScriptGenerator_without_json_tag(t *testing.T) {
+	loader := &mockSchemaLoader{
+		Schema: &generator.Schema{
+			Tables: map[string]*generator.Table{
+				"users": {
+					Name: "users",
+					Columns: []*generator.Column{
+						{Name: "id", DataType: "bigint"},
+						{Name: "deleted_at", DataType: "timestamp with time zone", Nullable: true, Comment: "Time of deletion.\nIt's null if the user is active."},
+					},
+				},
+			},
+		},
+	}
+	w := writer.NewMemoryWriter()
+	g := &generator.TypeScriptGenerator{
+		SchemaLoader: loader,
+		Writer:       w,
+		FileName:     "models.d.ts",
+	}
+	require.NoError(t, g.Generate())
+
+	content, err := fs.ReadFile(w.FS, "models.d.ts")
+	require.NoError(t, err)
+	require.Equal(t, `/** Users represents a row of users table. */
+export interface Users {
+  ID: number;
+  /**
+   * Time of deletion.
+   * It's null if the user is active.
+   */
+  DeletedAt: string | null;
+}
+`, string(content))
+}
@@ -0,0 +1,202 @@
+package generator
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// DefaultWatchInterval is the interval between two checks of Watcher if Interval isn't set.
+const DefaultWatchInterval = 2 * time.Second
+
+// Watcher reruns a Generator whenever migrations or the DB schema change.
+// Only tables whose definitions changed since the last run are regenerated.
+type Watcher struct {
+	Generator *Generator
+	// Interval is the interval between two checks.
+	Interval time.Duration
+	// MigrationDir is the directory containing migration files. The DB schema is loaded only after
+	// MigrationDir is changed and MigrateCommand applies migrations. If either is empty, migrations
+	// may be applied by other tools at any time, so the DB schema is loaded in every check to detect changes.
+	MigrationDir string
+	// MigrateCommand is a shell command to apply migrations after MigrationDir is changed.
+	MigrateCommand string
+	// Out receives logs of the watcher as well as outputs of MigrateCommand.
+	Out io.Writer
+
+	dirState     string
+	tables       map[string]string
+	queries      string
+	hasGenerated bool
+}
+
+// Watch generates code and keeps regenerating it until the context is cancelled.
+// Errors while regenerating are logged so that the watcher can recover from a bad migration.
+func (w *Watcher) Watch(ctx context.Context) error {
+	if err := w.Generator.validate(); err != nil {
+		return err
+	}
+
+	interval := w.Interval
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	// ctx is checked again as select picks a random case if the ticker fires after cancellation
+	for ctx.Err() == nil {
+		if err := w.check(ctx); err != nil {
+			w.logf("error: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-ticker.C:
+		}
+	}
+
+	return nil
+}
+
+func (w *Watcher) check(ctx context.Context) error {
+	if w.MigrationDir == "" || w.MigrateCommand == "" {
+		return w.load()
+	}
+
+	state, err := dirFingerprint(w.MigrationDir)
+	if err != nil {
+		return err
+	}
+
+	if state == w.dirState {
+		return nil
+	}
+
+	if w.hasGenerated {
+		w.logf("migrations changed, running %s", w.MigrateCommand)
+		if err := w.migrate(ctx); err != nil {
+			return err
+		}
+	}
+
+	if err := w.load(); err != nil {
+		return err
+	}
+
+	// the state is kept only after code is regenerated, so that a failed migration or generation is retried in the next check
+	w.dirState = state
+	return nil
+}
+
+func (w *Watcher) load() error {
+	schema, err := w.Generator.SchemaLoader.Load()
+	if err != nil {
+		return err
+	}
+
+	return w.regenerate(schema)
+}
+
+func (w *Watcher) regenerate(schema *Schema) error {
+	tables := map[string]string{}
+	var changed []string
+	for _, name := range w.Generator.tableNames(schema) {
+		table := schema.Tables[name]
+		if table == nil {
+			return fmt.Errorf("generator: %s couldn't be found in the schema", name)
+		}
+
+		// the hash covers what's generated besides the table, e.g. enums and parents of factories
+		tables[name] = w.Generator.tableHash(schema, table)
+		if w.tables[name] != tables[name] {
+			changed = append(changed, name)
+		}
+	}
+
+	var queries []*Query
+	queriesFingerprint := fingerprint(schema.Queries)
+	if queriesFingerprint != w.queries {
+		queries = schema.Queries
+	}
+
+	if w.hasGenerated && len(changed) == 0 && len(queries) == 0 {
+		return nil
+	}
+
+	if err := w.Generator.generate(schema, changed, queries); err != nil {
+		return err
+	}
+
+	w.tables = tables
+	w.queries = queriesFingerprint
+	w.hasGenerated = true
+	for _, name := range changed {
+		w.logf("generated %s", name)
+	}
+
+	if len(queries) > 0 {
+		w.logf("generated queries")
+	}
+
+	return nil
+}
+
+func (w *Watcher) migrate(ctx context.Context) error {
+	cmd := exec.CommandContext(ctx, "sh", "-c", w.MigrateCommand)
+	cmd.Stdout = w.Out
+	cmd.Stderr = w.Out
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("generator: failed to run migrate command: %w", err)
+	}
+
+	return nil
+}
+
+func (w *Watcher) logf(format string, args ...interface{}) {
+	if w.Out == nil {
+		return
+	}
+
+	fmt.Fprintf(w.Out, "pggo: "+format+"\n", args...)
+}
+
+// dirFingerprint returns a hash of names, sizes and modification times of files in a directory.
+func dirFingerprint(dir string) (string, error) {
+	var entries []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		entries = append(entries, fmt.Sprintf("%s:%d:%d", path, info.Size(), info.ModTime().UnixNano()))
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	sort.Strings(entries)
+	return fingerprint(entries), nil
+}
+
+func fingerprint(v interface{}) string {
+	// values are plain structs, slices and maps so encoding can't fail
+	content, _ := json.Marshal(v)
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
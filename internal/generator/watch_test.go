@@ -0,0 +1,265 @@
+package generator_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bongnv/pggo/internal/generator"
+)
+
+// sequenceLoader returns schemas in order and cancels the watcher after the last one if cancel is set.
+type sequenceLoader struct {
+	schemas []*generator.Schema
+	errs    []error
+	loads   int
+	cancel  context.CancelFunc
+}
+
+func (l *sequenceLoader) Load() (*generator.Schema, error) {
+	i := l.loads
+	l.loads++
+	if l.loads == len(l.schemas) && l.cancel != nil {
+		l.cancel()
+	}
+
+	return l.schemas[i], l.errs[i]
+}
+
+type fileNameWriter struct {
+	files []string
+}
+
+func (w *fileNameWriter) Write(fileName string, _ []byte) error {
+	w.files = append(w.files, fileName)
+	return nil
+}
+
+func watchSchema(nameType string) *generator.Schema {
+	return &generator.Schema{
+		Tables: map[string]*generator.Table{
+			"users": {
+				Name: "users",
+				Columns: []*generator.Column{
+					{Name: "id", DataType: "bigint"},
+					{Name: "name", DataType: nameType},
+				},
+			},
+			"orders": {
+				Name: "orders",
+				Columns: []*generator.Column{
+					{Name: "id", DataType: "bigint"},
+				},
+			},
+		},
+	}
+}
+
+func Test_Watcher(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	loader := &sequenceLoader{
+		schemas: []*generator.Schema{watchSchema("text"), watchSchema("text"), nil, watchSchema("varchar")},
+		errs:    []error{nil, nil, errors.New("random error"), nil},
+		cancel:  cancel,
+	}
+	w := &fileNameWriter{}
+	out := &bytes.Buffer{}
+	watcher := &generator.Watcher{
+		Generator: &generator.Generator{
+			SchemaLoader: loader,
+			Tables:       []string{"users", "orders"},
+			Writer:       w,
+		},
+		Interval: time.Millisecond,
+		Out:      out,
+	}
+
+	require.NoError(t, watcher.Watch(ctx))
+	require.Equal(t, 4, loader.loads)
+	require.Equal(t, []string{
+		"users.pggo.go",
+		"schema/users.pggo.go",
+		"orders.pggo.go",
+		"schema/orders.pggo.go",
+		"table_definitions.pggo.go",
+		"users.pggo.go",
+		"schema/users.pggo.go",
+		"table_definitions.pggo.go",
+	}, w.files)
+	require.Equal(t, "pggo: generated users\npggo: generated orders\npggo: error: random error\npggo: generated users\n", out.String())
+}
+
+func Test_Watcher_migration_dir(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// the schema is loaded only once as migrations are unchanged
+	loader := &sequenceLoader{
+		schemas: []*generator.Schema{watchSchema("text")},
+		errs:    []error{nil},
+	}
+	watcher := &generator.Watcher{
+		Generator: &generator.Generator{
+			SchemaLoader: loader,
+			Tables:       []string{"users"},
+			Writer:       &fileNameWriter{},
+		},
+		Interval:       time.Millisecond,
+		MigrationDir:   t.TempDir(),
+		MigrateCommand: "true",
+	}
+
+	require.NoError(t, watcher.Watch(ctx))
+	require.Equal(t, 1, loader.loads)
+}
+
+func Test_Watcher_migration_dir_without_command(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// the schema is polled as migrations may be applied by other tools
+	loader := &sequenceLoader{
+		schemas: []*generator.Schema{watchSchema("text"), watchSchema("varchar")},
+		errs:    []error{nil, nil},
+		cancel:  cancel,
+	}
+	w := &fileNameWriter{}
+	watcher := &generator.Watcher{
+		Generator: &generator.Generator{
+			SchemaLoader: loader,
+			Tables:       []string{"users"},
+			Writer:       w,
+		},
+		Interval:     time.Millisecond,
+		MigrationDir: t.TempDir(),
+	}
+
+	require.NoError(t, watcher.Watch(ctx))
+	require.Equal(t, 2, loader.loads)
+	require.Equal(t, []string{
+		"users.pggo.go",
+		"schema/users.pggo.go",
+		"table_definitions.pggo.go",
+		"users.pggo.go",
+		"schema/users.pggo.go",
+		"table_definitions.pggo.go",
+	}, w.files)
+}
+
+func Test_Watcher_failed_load(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// unchanged migrations are loaded again as the first load failed
+	loader := &sequenceLoader{
+		schemas: []*generator.Schema{nil, watchSchema("text")},
+		errs:    []error{errors.New("random error"), nil},
+		cancel:  cancel,
+	}
+	w := &fileNameWriter{}
+	out := &bytes.Buffer{}
+	watcher := &generator.Watcher{
+		Generator: &generator.Generator{
+			SchemaLoader: loader,
+			Tables:       []string{"users"},
+			Writer:       w,
+		},
+		Interval:       time.Millisecond,
+		MigrationDir:   t.TempDir(),
+		MigrateCommand: "true",
+		Out:            out,
+	}
+
+	require.NoError(t, watcher.Watch(ctx))
+	require.Equal(t, 2, loader.loads)
+	require.Equal(t, []string{"users.pggo.go", "schema/users.pggo.go", "table_definitions.pggo.go"}, w.files)
+	require.Equal(t, "pggo: error: random error\npggo: generated users\n", out.String())
+}
+
+func Test_Watcher_enum_changes(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	schemas := []*generator.Schema{watchSchema("mood"), watchSchema("mood")}
+	schemas[0].Enums = map[string]*generator.Enum{"mood": {Name: "mood", Values: []string{"happy"}}}
+	schemas[1].Enums = map[string]*generator.Enum{"mood": {Name: "mood", Values: []string{"happy", "sad"}}}
+	loader := &sequenceLoader{
+		schemas: schemas,
+		errs:    []error{nil, nil},
+		cancel:  cancel,
+	}
+	w := &fileNameWriter{}
+	watcher := &generator.Watcher{
+		Generator: &generator.Generator{
+			SchemaLoader: loader,
+			Tables:       []string{"users"},
+			Writer:       w,
+			Factories:    true,
+		},
+		Interval: time.Millisecond,
+	}
+
+	// users is regenerated as its factory depends on the enum
+	require.NoError(t, watcher.Watch(ctx))
+	require.Equal(t, []string{
+		"users.pggo.go",
+		"schema/users.pggo.go",
+		"users_factory.pggo.go",
+		"table_definitions.pggo.go",
+		"users.pggo.go",
+		"schema/users.pggo.go",
+		"users_factory.pggo.go",
+		"table_definitions.pggo.go",
+	}, w.files)
+}
+
+// migrationLoader adds a migration file after the first load.
+type migrationLoader struct {
+	dir   string
+	loads int
+}
+
+func (l *migrationLoader) Load() (*generator.Schema, error) {
+	l.loads++
+	if l.loads == 1 {
+		if err := os.WriteFile(filepath.Join(l.dir, "V1__init.sql"), []byte("SELECT 1;"), 0600); err != nil {
+			return nil, err
+		}
+	}
+
+	return watchSchema("text"), nil
+}
+
+func Test_Watcher_failed_migration(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	dir := t.TempDir()
+	loader := &migrationLoader{dir: dir}
+	out := &bytes.Buffer{}
+	watcher := &generator.Watcher{
+		Generator: &generator.Generator{
+			SchemaLoader: loader,
+			Tables:       []string{"users"},
+			Writer:       &fileNameWriter{},
+		},
+		Interval:       time.Millisecond,
+		MigrationDir:   dir,
+		MigrateCommand: "exit 1",
+		Out:            out,
+	}
+
+	// the failed migration is retried and the schema isn't loaded again
+	require.NoError(t, watcher.Watch(ctx))
+	require.Equal(t, 1, loader.loads)
+	require.Greater(t, strings.Count(out.String(), "pggo: migrations changed, running exit 1\n"), 1)
+}
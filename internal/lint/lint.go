@@ -0,0 +1,326 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bongnv/pggo/internal/generator"
+)
+
+// Levels of issues.
+const (
+	LevelWarning = "warning"
+	LevelError   = "error"
+)
+
+// Issue represents a problem found by a rule.
+type Issue struct {
+	Rule    string `json:"rule"`
+	Level   string `json:"level"`
+	Table   string `json:"table"`
+	Column  string `json:"column,omitempty"`
+	Message string `json:"message"`
+}
+
+// Location returns the name of the table or the column having the issue, e.g. "users.name".
+func (i *Issue) Location() string {
+	if i.Column == "" {
+		return i.Table
+	}
+
+	return i.Table + "." + i.Column
+}
+
+// Rule checks tables in a schema.
+type Rule struct {
+	Name        string
+	Description string
+	Level       string
+	check       func(schema *generator.Schema, t *generator.Table) []*Issue
+}
+
+// Rules contains all available rules, they are enabled by default.
+var Rules = []*Rule{
+	{
+		Name:        "no-primary-key",
+		Description: "Tables should have a primary key.",
+		Level:       LevelWarning,
+		check:       checkPrimaryKey,
+	},
+	{
+		Name:        "unindexed-foreign-key",
+		Description: "Columns of foreign keys should be the leading columns of an index.",
+		Level:       LevelWarning,
+		check:       checkForeignKeyIndexes,
+	},
+	{
+		Name:        "nullable-boolean",
+		Description: "Boolean columns should be NOT NULL to avoid three-valued logic.",
+		Level:       LevelWarning,
+		check:       checkNullableBooleans,
+	},
+	{
+		Name:        "timestamp-without-time-zone",
+		Description: "Timestamp columns should use timestamp with time zone.",
+		Level:       LevelWarning,
+		check:       checkTimestamps,
+	},
+	{
+		Name:        "varchar-without-length",
+		Description: "Character varying columns should have a length, or use text instead.",
+		Level:       LevelWarning,
+		check:       checkVarchars,
+	},
+	{
+		Name:        "reserved-column-name",
+		Description: "Column names shouldn't be reserved SQL keywords.",
+		Level:       LevelWarning,
+		check:       checkReservedColumnNames,
+	},
+	{
+		Name:        "go-identifier-collision",
+		Description: "Names of tables and columns shouldn't produce colliding Go identifiers.",
+		Level:       LevelError,
+		check:       checkGoIdentifiers,
+	},
+}
+
+// Linter runs rules over DB schema.
+type Linter struct {
+	SchemaLoader generator.SchemaLoader
+	// Rules enables or disables rules by names, rules are enabled if they aren't listed.
+	Rules map[string]bool
+}
+
+// Lint loads the schema and returns issues found by enabled rules.
+// Issues are sorted by tables, then by the order of rules.
+func (l *Linter) Lint() ([]*Issue, error) {
+	rules, err := l.enabledRules()
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := l.SchemaLoader.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []*Issue
+	for _, t := range schema.SortedTables() {
+		for _, r := range rules {
+			for _, issue := range r.check(schema, t) {
+				issue.Rule = r.Name
+				issue.Level = r.Level
+				issue.Table = t.Name
+				issues = append(issues, issue)
+			}
+		}
+	}
+
+	return issues, nil
+}
+
+func (l *Linter) enabledRules() ([]*Rule, error) {
+	for name := range l.Rules {
+		if findRule(name) == nil {
+			return nil, fmt.Errorf("lint: unknown rule %s", name)
+		}
+	}
+
+	var rules []*Rule
+	for _, r := range Rules {
+		if enabled, ok := l.Rules[r.Name]; !ok || enabled {
+			rules = append(rules, r)
+		}
+	}
+
+	return rules, nil
+}
+
+func findRule(name string) *Rule {
+	for _, r := range Rules {
+		if r.Name == name {
+			return r
+		}
+	}
+
+	return nil
+}
+
+func checkPrimaryKey(_ *generator.Schema, t *generator.Table) []*Issue {
+	// partitions and children can't have primary keys if their parents don't
+	if t.PrimaryKey != nil || t.Parent != "" {
+		return nil
+	}
+
+	return []*Issue{{Message: "table has no primary key"}}
+}
+
+func checkForeignKeyIndexes(_ *generator.Schema, t *generator.Table) []*Issue {
+	var issues []*Issue
+	for _, fk := range t.ForeignKeys {
+		if !hasLeadingIndex(t, fk.Columns) {
+			issues = append(issues, &Issue{
+				Message: fmt.Sprintf("foreign key %s (%s) has no supporting index", fk.Name, strings.Join(fk.Columns, ", ")),
+			})
+		}
+	}
+
+	return issues
+}
+
+// hasLeadingIndex returns true if the columns are the leading columns of an index in any order.
+func hasLeadingIndex(t *generator.Table, cols []string) bool {
+	for _, index := range t.Indexes {
+		if len(index.Columns) < len(cols) {
+			continue
+		}
+
+		leading := map[string]bool{}
+		for _, name := range index.Columns[:len(cols)] {
+			leading[name] = true
+		}
+
+		covered := true
+		for _, name := range cols {
+			covered = covered && leading[name]
+		}
+
+		if covered {
+			return true
+		}
+	}
+
+	return false
+}
+
+func checkNullableBooleans(_ *generator.Schema, t *generator.Table) []*Issue {
+	return checkColumns(t, func(c *generator.Column) string {
+		if c.DataType == "boolean" && c.Nullable {
+			return "boolean column is nullable"
+		}
+
+		return ""
+	})
+}
+
+func checkTimestamps(_ *generator.Schema, t *generator.Table) []*Issue {
+	return checkColumns(t, func(c *generator.Column) string {
+		if c.DataType == "timestamp without time zone" {
+			return "column uses timestamp without time zone"
+		}
+
+		return ""
+	})
+}
+
+func checkVarchars(_ *generator.Schema, t *generator.Table) []*Issue {
+	return checkColumns(t, func(c *generator.Column) string {
+		if c.DataType == "character varying" && c.MaxLength == 0 {
+			return "character varying column has no length"
+		}
+
+		return ""
+	})
+}
+
+func checkReservedColumnNames(_ *generator.Schema, t *generator.Table) []*Issue {
+	return checkColumns(t, func(c *generator.Column) string {
+		if reservedKeywords[strings.ToLower(c.Name)] {
+			return fmt.Sprintf("column name %s is a reserved keyword", c.Name)
+		}
+
+		return ""
+	})
+}
+
+// entityMethods contains methods generated for models, fields can't have the same names.
+var entityMethods = map[string]bool{
+	"GetPointers": true,
+	"GetValues":   true,
+}
+
+func checkGoIdentifiers(schema *generator.Schema, t *generator.Table) []*Issue {
+	var issues []*Issue
+	identifiers := map[string]bool{}
+	for _, id := range t.Identifiers() {
+		identifiers[id] = true
+	}
+
+	var others []string
+	for _, other := range schema.SortedTables() {
+		if other.Name == t.Name {
+			continue
+		}
+
+		for _, id := range other.Identifiers() {
+			if identifiers[id] {
+				others = append(others, other.Name)
+				break
+			}
+		}
+	}
+
+	if len(others) > 0 {
+		issues = append(issues, &Issue{
+			Message: fmt.Sprintf("Go identifiers of the table collide with identifiers of %s", strings.Join(others, ", ")),
+		})
+	}
+
+	fields := map[string]string{}
+	for _, c := range t.Columns {
+		name := c.GoName()
+		switch {
+		case entityMethods[name]:
+			issues = append(issues, &Issue{
+				Column:  c.Name,
+				Message: fmt.Sprintf("Go field %s collides with the method of the model", name),
+			})
+		case fields[name] != "":
+			issues = append(issues, &Issue{
+				Column:  c.Name,
+				Message: fmt.Sprintf("Go field %s collides with the field of column %s", name, fields[name]),
+			})
+		default:
+			fields[name] = c.Name
+		}
+	}
+
+	return issues
+}
+
+func checkColumns(t *generator.Table, check func(c *generator.Column) string) []*Issue {
+	var issues []*Issue
+	for _, c := range t.Columns {
+		if msg := check(c); msg != "" {
+			issues = append(issues, &Issue{
+				Column:  c.Name,
+				Message: msg,
+			})
+		}
+	}
+
+	return issues
+}
+
+// reservedKeywords contains reserved keywords of PostgreSQL, including ones that can be function or type names.
+var reservedKeywords = map[string]bool{
+	"all": true, "analyse": true, "analyze": true, "and": true, "any": true, "array": true,
+	"as": true, "asc": true, "asymmetric": true, "authorization": true, "binary": true, "both": true,
+	"case": true, "cast": true, "check": true, "collate": true, "collation": true, "column": true,
+	"concurrently": true, "constraint": true, "create": true, "cross": true, "current_catalog": true,
+	"current_date": true, "current_role": true, "current_schema": true, "current_time": true,
+	"current_timestamp": true, "current_user": true, "default": true, "deferrable": true,
+	"desc": true, "distinct": true, "do": true, "else": true, "end": true, "except": true,
+	"false": true, "fetch": true, "for": true, "foreign": true, "freeze": true, "from": true,
+	"full": true, "grant": true, "group": true, "having": true, "ilike": true, "in": true,
+	"initially": true, "inner": true, "intersect": true, "into": true, "is": true, "isnull": true,
+	"join": true, "lateral": true, "leading": true, "left": true, "like": true, "limit": true,
+	"localtime": true, "localtimestamp": true, "natural": true, "not": true, "notnull": true,
+	"null": true, "offset": true, "on": true, "only": true, "or": true, "order": true, "outer": true,
+	"overlaps": true, "placing": true, "primary": true, "references": true, "returning": true,
+	"right": true, "select": true, "session_user": true, "similar": true, "some": true,
+	"symmetric": true, "table": true, "tablesample": true, "then": true, "to": true, "trailing": true,
+	"true": true, "union": true, "unique": true, "user": true, "using": true, "variadic": true,
+	"verbose": true, "when": true, "where": true, "window": true, "with": true,
+}
@@ -0,0 +1,112 @@
+package lint_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bongnv/pggo/internal/generator"
+	"github.com/bongnv/pggo/internal/lint"
+)
+
+type mockSchemaLoader struct {
+	Schema *generator.Schema
+	Err    error
+}
+
+func (l mockSchemaLoader) Load() (*generator.Schema, error) {
+	return l.Schema, l.Err
+}
+
+func lintSchema() *generator.Schema {
+	return &generator.Schema{
+		Tables: map[string]*generator.Table{
+			"users": {
+				Name: "users",
+				Columns: []*generator.Column{
+					{Name: "id", DataType: "bigint"},
+					{Name: "org_id", DataType: "bigint"},
+					{Name: "name", DataType: "character varying"},
+					{Name: "code", DataType: "character varying", MaxLength: 16},
+					{Name: "active", DataType: "boolean", Nullable: true},
+					{Name: "created_at", DataType: "timestamp without time zone"},
+					{Name: "order", DataType: "integer"},
+					{Name: "get_values", DataType: "text"},
+				},
+				PrimaryKey: &generator.Constraint{Name: "users_pkey", Columns: []string{"id"}},
+				ForeignKeys: []*generator.ForeignKey{
+					{Name: "users_org_id_fkey", Columns: []string{"org_id"}, RefTable: "orgs", RefColumns: []string{"id"}},
+				},
+			},
+			"users_list": {
+				Name: "users_list",
+				Columns: []*generator.Column{
+					{Name: "user_id", DataType: "bigint"},
+					{Name: "user_ID", DataType: "bigint"},
+				},
+				PrimaryKey: &generator.Constraint{Name: "users_list_pkey", Columns: []string{"user_id"}},
+			},
+			"orgs": {
+				Name: "orgs",
+				Columns: []*generator.Column{
+					{Name: "id", DataType: "bigint"},
+					{Name: "parent_id", DataType: "bigint"},
+				},
+				ForeignKeys: []*generator.ForeignKey{
+					{Name: "orgs_parent_id_fkey", Columns: []string{"parent_id"}, RefTable: "orgs", RefColumns: []string{"id"}},
+				},
+				Indexes: []*generator.Index{
+					{Name: "orgs_parent_id_id_idx", Columns: []string{"parent_id", "id"}},
+				},
+			},
+		},
+	}
+}
+
+func Test_Linter(t *testing.T) {
+	l := &lint.Linter{
+		SchemaLoader: &mockSchemaLoader{Schema: lintSchema()},
+	}
+	issues, err := l.Lint()
+	require.NoError(t, err)
+	require.Equal(t, []*lint.Issue{
+		{Rule: "no-primary-key", Level: lint.LevelWarning, Table: "orgs", Message: "table has no primary key"},
+		{Rule: "unindexed-foreign-key", Level: lint.LevelWarning, Table: "users", Message: "foreign key users_org_id_fkey (org_id) has no supporting index"},
+		{Rule: "nullable-boolean", Level: lint.LevelWarning, Table: "users", Column: "active", Message: "boolean column is nullable"},
+		{Rule: "timestamp-without-time-zone", Level: lint.LevelWarning, Table: "users", Column: "created_at", Message: "column uses timestamp without time zone"},
+		{Rule: "varchar-without-length", Level: lint.LevelWarning, Table: "users", Column: "name", Message: "character varying column has no length"},
+		{Rule: "reserved-column-name", Level: lint.LevelWarning, Table: "users", Column: "order", Message: "column name order is a reserved keyword"},
+		{Rule: "go-identifier-collision", Level: lint.LevelError, Table: "users", Message: "Go identifiers of the table collide with identifiers of users_list"},
+		{Rule: "go-identifier-collision", Level: lint.LevelError, Table: "users", Column: "get_values", Message: "Go field GetValues collides with the method of the model"},
+		{Rule: "go-identifier-collision", Level: lint.LevelError, Table: "users_list", Message: "Go identifiers of the table collide with identifiers of users"},
+		{Rule: "go-identifier-collision", Level: lint.LevelError, Table: "users_list", Column: "user_ID", Message: "Go field UserID collides with the field of column user_id"},
+	}, issues)
+}
+
+func Test_Linter_rules(t *testing.T) {
+	l := &lint.Linter{
+		SchemaLoader: &mockSchemaLoader{Schema: lintSchema()},
+		Rules: map[string]bool{
+			"no-primary-key":              true,
+			"unindexed-foreign-key":       false,
+			"nullable-boolean":            false,
+			"timestamp-without-time-zone": false,
+			"varchar-without-length":      false,
+			"reserved-column-name":        false,
+			"go-identifier-collision":     false,
+		},
+	}
+	issues, err := l.Lint()
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	require.Equal(t, "no-primary-key", issues[0].Rule)
+}
+
+func Test_Linter_unknown_rule(t *testing.T) {
+	l := &lint.Linter{
+		SchemaLoader: &mockSchemaLoader{Schema: lintSchema()},
+		Rules:        map[string]bool{"no-foo": false},
+	}
+	_, err := l.Lint()
+	require.EqualError(t, err, "lint: unknown rule no-foo")
+}
@@ -0,0 +1,168 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+)
+
+// Output formats of issues.
+const (
+	FormatText  = "text"
+	FormatJSON  = "json"
+	FormatSARIF = "sarif"
+)
+
+// Write writes issues in the given format.
+// SARIF results are located in the artifact, e.g. the config file, since issues of a database schema have no file.
+func Write(w io.Writer, format, artifact string, issues []*Issue) error {
+	switch format {
+	case FormatText:
+		return writeText(w, issues)
+	case FormatJSON:
+		return writeJSON(w, issues)
+	case FormatSARIF:
+		return writeSARIF(w, artifact, issues)
+	default:
+		return fmt.Errorf("lint: unknown format %s", format)
+	}
+}
+
+func writeText(w io.Writer, issues []*Issue) error {
+	for _, issue := range issues {
+		if _, err := fmt.Fprintf(w, "%s: %s: %s [%s]\n", issue.Location(), issue.Level, issue.Message, issue.Rule); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeJSON(w io.Writer, issues []*Issue) error {
+	if issues == nil {
+		issues = []*Issue{}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(issues)
+}
+
+// SARIF 2.1.0 is used by code scanning tools, see https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string       `json:"id"`
+	ShortDescription     sarifMessage `json:"shortDescription"`
+	DefaultConfiguration sarifConfig  `json:"defaultConfiguration"`
+}
+
+type sarifConfig struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation  `json:"physicalLocation"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+// sarifPhysicalLocation has no region as tables and columns aren't declared in the artifact.
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+func writeSARIF(w io.Writer, artifact string, issues []*Issue) error {
+	driver := sarifDriver{
+		Name:           "pggo",
+		InformationURI: "https://github.com/bongnv/pggo",
+	}
+
+	ruleIndexes := map[string]int{}
+	for i, r := range Rules {
+		ruleIndexes[r.Name] = i
+		driver.Rules = append(driver.Rules, sarifRule{
+			ID:                   r.Name,
+			ShortDescription:     sarifMessage{Text: r.Description},
+			DefaultConfiguration: sarifConfig{Level: r.Level},
+		})
+	}
+
+	results := []sarifResult{}
+	for _, issue := range issues {
+		kind := "table"
+		if issue.Column != "" {
+			kind = "column"
+		}
+
+		results = append(results, sarifResult{
+			RuleID:    issue.Rule,
+			RuleIndex: ruleIndexes[issue.Rule],
+			Level:     issue.Level,
+			Message:   sarifMessage{Text: issue.Message},
+			Locations: []sarifLocation{
+				{
+					PhysicalLocation: sarifPhysicalLocation{
+						ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(artifact)},
+					},
+					LogicalLocations: []sarifLogicalLocation{
+						{FullyQualifiedName: issue.Location(), Kind: kind},
+					},
+				},
+			},
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{
+			{
+				Tool:    sarifTool{Driver: driver},
+				Results: results,
+			},
+		},
+	})
+}
@@ -0,0 +1,93 @@
+package lint_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bongnv/pggo/internal/lint"
+)
+
+func outputIssues() []*lint.Issue {
+	return []*lint.Issue{
+		{Rule: "no-primary-key", Level: lint.LevelWarning, Table: "orgs", Message: "table has no primary key"},
+		{Rule: "go-identifier-collision", Level: lint.LevelError, Table: "users", Column: "get_values", Message: "Go field GetValues collides with the method of the model"},
+	}
+}
+
+func Test_Write_text(t *testing.T) {
+	buf := &bytes.Buffer{}
+	require.NoError(t, lint.Write(buf, lint.FormatText, "", outputIssues()))
+	require.Equal(t, "orgs: warning: table has no primary key [no-primary-key]\n"+
+		"users.get_values: error: Go field GetValues collides with the method of the model [go-identifier-collision]\n", buf.String())
+}
+
+func Test_Write_json(t *testing.T) {
+	buf := &bytes.Buffer{}
+	require.NoError(t, lint.Write(buf, lint.FormatJSON, "", nil))
+	require.Equal(t, "[]\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, lint.Write(buf, lint.FormatJSON, "", outputIssues()))
+	require.JSONEq(t, `[
+  {"rule": "no-primary-key", "level": "warning", "table": "orgs", "message": "table has no primary key"},
+  {"rule": "go-identifier-collision", "level": "error", "table": "users", "column": "get_values", "message": "Go field GetValues collides with the method of the model"}
+]`, buf.String())
+}
+
+func Test_Write_sarif(t *testing.T) {
+	buf := &bytes.Buffer{}
+	require.NoError(t, lint.Write(buf, lint.FormatSARIF, "pggo.yaml", outputIssues()))
+
+	var log struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Name  string `json:"name"`
+					Rules []struct {
+						ID string `json:"id"`
+					} `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []struct {
+				RuleID    string `json:"ruleId"`
+				RuleIndex int    `json:"ruleIndex"`
+				Level     string `json:"level"`
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct {
+							URI string `json:"uri"`
+						} `json:"artifactLocation"`
+					} `json:"physicalLocation"`
+					LogicalLocations []struct {
+						FullyQualifiedName string `json:"fullyQualifiedName"`
+						Kind               string `json:"kind"`
+					} `json:"logicalLocations"`
+				} `json:"locations"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &log))
+	require.Equal(t, "2.1.0", log.Version)
+	require.Len(t, log.Runs, 1)
+
+	run := log.Runs[0]
+	require.Equal(t, "pggo", run.Tool.Driver.Name)
+	require.Len(t, run.Tool.Driver.Rules, len(lint.Rules))
+	require.Len(t, run.Results, 2)
+
+	result := run.Results[1]
+	require.Equal(t, "go-identifier-collision", result.RuleID)
+	require.Equal(t, "go-identifier-collision", run.Tool.Driver.Rules[result.RuleIndex].ID)
+	require.Equal(t, "error", result.Level)
+	require.Equal(t, "pggo.yaml", result.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	require.Equal(t, "users.get_values", result.Locations[0].LogicalLocations[0].FullyQualifiedName)
+	require.Equal(t, "column", result.Locations[0].LogicalLocations[0].Kind)
+}
+
+func Test_Write_unknown_format(t *testing.T) {
+	require.EqualError(t, lint.Write(&bytes.Buffer{}, "xml", "", nil), "lint: unknown format xml")
+}
@@ -2,6 +2,9 @@ package loader
 
 import (
 	"context"
+	"fmt"
+	"regexp"
+	"sort"
 
 	"github.com/jackc/pgx/v4"
 
@@ -11,6 +14,11 @@ import (
 // PostgreSQLLoader is an implementation to load schema from PostgreSQL DB.
 type PostgreSQLLoader struct {
 	URL string
+	// QueryFiles is a list of SQL files containing annotated queries.
+	QueryFiles []string
+	// Partitions includes partitions and inheritance children in the schema.
+	// By default, only their parent tables are loaded.
+	Partitions bool
 }
 
 // Load connects to the given URL to load DB schema.
@@ -22,7 +30,74 @@ func (l PostgreSQLLoader) Load() (*generator.Schema, error) {
 	}
 	defer conn.Close(ctx)
 
-	rows, err := conn.Query(ctx, "SELECT table_name FROM information_schema.tables WHERE table_schema = 'public' ORDER BY table_name")
+	tables, err := fetchTables(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	if !l.Partitions {
+		for name, table := range tables {
+			if table.Parent != "" {
+				delete(tables, name)
+			}
+		}
+	}
+
+	if err := fetchColumns(conn, tables); err != nil {
+		return nil, err
+	}
+
+	if err := fetchConstraints(conn, tables); err != nil {
+		return nil, err
+	}
+
+	if err := fetchForeignKeys(conn, tables); err != nil {
+		return nil, err
+	}
+
+	if err := fetchChecks(conn, tables); err != nil {
+		return nil, err
+	}
+
+	if err := fetchIndexes(conn, tables); err != nil {
+		return nil, err
+	}
+
+	enums, err := fetchEnums(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	queries, err := l.loadQueries(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	return &generator.Schema{
+		Tables:  tables,
+		Enums:   enums,
+		Queries: queries,
+	}, nil
+}
+
+// fetchTables loads tables with their partitioning and inheritance information.
+func fetchTables(conn *pgx.Conn) (map[string]*generator.Table, error) {
+	ctx := context.Background()
+	rows, err := conn.Query(ctx, `SELECT c.relname, COALESCE(obj_description(c.oid, 'pg_class'), ''),
+  COALESCE(p.relname, ''), COALESCE(pg_catalog.pg_get_expr(c.relpartbound, c.oid), ''),
+  CASE pt.partstrat WHEN 'r' THEN 'range' WHEN 'l' THEN 'list' WHEN 'h' THEN 'hash' ELSE '' END,
+  COALESCE(pg_catalog.pg_get_partkeydef(c.oid), ''),
+  ARRAY(
+    SELECT a.attname::text FROM unnest(pt.partattrs::int2[]) WITH ORDINALITY k(attnum, ord)
+    JOIN pg_catalog.pg_attribute a ON a.attrelid = pt.partrelid AND a.attnum = k.attnum ORDER BY k.ord
+  )
+FROM pg_catalog.pg_class c
+JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+LEFT JOIN pg_catalog.pg_inherits i ON i.inhrelid = c.oid AND i.inhseqno = 1
+LEFT JOIN pg_catalog.pg_class p ON p.oid = i.inhparent
+LEFT JOIN pg_catalog.pg_partitioned_table pt ON pt.partrelid = c.oid
+WHERE n.nspname = 'public' AND c.relkind IN ('r', 'p', 'v', 'f')
+ORDER BY c.relname`)
 	if err != nil {
 		return nil, err
 	}
@@ -30,28 +105,343 @@ func (l PostgreSQLLoader) Load() (*generator.Schema, error) {
 	defer rows.Close()
 
 	tables := map[string]*generator.Table{}
-
 	for rows.Next() {
 		table := &generator.Table{}
-		if err := rows.Scan(&table.Name); err != nil {
+		key := &generator.PartitionKey{}
+		if err := rows.Scan(&table.Name, &table.Comment, &table.Parent, &table.PartitionBound, &key.Strategy, &key.Definition, &key.Columns); err != nil {
 			return nil, err
 		}
 
+		if key.Strategy != "" {
+			table.PartitionKey = key
+		}
+
 		tables[table.Name] = table
 	}
 
-	if err := fetchColumns(conn, tables); err != nil {
+	if err := rows.Err(); err != nil {
 		return nil, err
 	}
 
-	return &generator.Schema{
-		Tables: tables,
-	}, nil
+	names := make([]string, 0, len(tables))
+	for name := range tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		table := tables[name]
+		if parent := tables[table.Parent]; parent != nil {
+			parent.Partitions = append(parent.Partitions, table.Name)
+		}
+	}
+
+	return tables, nil
+}
+
+func fetchEnums(conn *pgx.Conn) (map[string]*generator.Enum, error) {
+	ctx := context.Background()
+	rows, err := conn.Query(ctx, `SELECT t.typname,
+  ARRAY(SELECT e.enumlabel::text FROM pg_catalog.pg_enum e WHERE e.enumtypid = t.oid ORDER BY e.enumsortorder)
+FROM pg_catalog.pg_type t
+JOIN pg_catalog.pg_namespace n ON n.oid = t.typnamespace
+WHERE t.typtype = 'e' AND n.nspname = 'public'`)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	enums := map[string]*generator.Enum{}
+	for rows.Next() {
+		enum := &generator.Enum{}
+		if err := rows.Scan(&enum.Name, &enum.Values); err != nil {
+			return nil, err
+		}
+
+		enums[enum.Name] = enum
+	}
+
+	return enums, rows.Err()
+}
+
+func fetchConstraints(conn *pgx.Conn, tables map[string]*generator.Table) error {
+	ctx := context.Background()
+	rows, err := conn.Query(ctx, `SELECT tc.table_name, tc.constraint_name, tc.constraint_type, kcu.column_name
+FROM information_schema.table_constraints tc
+JOIN information_schema.key_column_usage kcu
+  ON kcu.constraint_schema = tc.constraint_schema AND kcu.constraint_name = tc.constraint_name AND kcu.table_name = tc.table_name
+WHERE tc.table_schema = 'public' AND tc.constraint_type IN ('PRIMARY KEY', 'UNIQUE')
+ORDER BY tc.table_name, tc.constraint_name, kcu.ordinal_position`)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	var current *generator.Constraint
+	var currentTable string
+	for rows.Next() {
+		var tableName, constraintName, constraintType, columnName string
+		if err := rows.Scan(&tableName, &constraintName, &constraintType, &columnName); err != nil {
+			return err
+		}
+
+		table := tables[tableName]
+		if table == nil {
+			continue
+		}
+
+		if current == nil || currentTable != tableName || current.Name != constraintName {
+			currentTable = tableName
+			current = &generator.Constraint{
+				Name: constraintName,
+			}
+
+			if constraintType == "PRIMARY KEY" {
+				table.PrimaryKey = current
+			} else {
+				table.UniqueKeys = append(table.UniqueKeys, current)
+			}
+		}
+
+		current.Columns = append(current.Columns, columnName)
+	}
+
+	return rows.Err()
+}
+
+func fetchForeignKeys(conn *pgx.Conn, tables map[string]*generator.Table) error {
+	ctx := context.Background()
+	rows, err := conn.Query(ctx, `SELECT cl.relname, c.conname, rcl.relname,
+  ARRAY(
+    SELECT a.attname::text FROM unnest(c.conkey) WITH ORDINALITY k(attnum, ord)
+    JOIN pg_catalog.pg_attribute a ON a.attrelid = c.conrelid AND a.attnum = k.attnum ORDER BY k.ord
+  ),
+  ARRAY(
+    SELECT a.attname::text FROM unnest(c.confkey) WITH ORDINALITY k(attnum, ord)
+    JOIN pg_catalog.pg_attribute a ON a.attrelid = c.confrelid AND a.attnum = k.attnum ORDER BY k.ord
+  ),
+  `+referentialAction("c.confdeltype")+`, `+referentialAction("c.confupdtype")+`
+FROM pg_catalog.pg_constraint c
+JOIN pg_catalog.pg_class cl ON cl.oid = c.conrelid
+JOIN pg_catalog.pg_namespace n ON n.oid = cl.relnamespace
+JOIN pg_catalog.pg_class rcl ON rcl.oid = c.confrelid
+WHERE c.contype = 'f' AND n.nspname = 'public'
+ORDER BY cl.relname, c.conname`)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var tableName string
+		fk := &generator.ForeignKey{}
+		if err := rows.Scan(&tableName, &fk.Name, &fk.RefTable, &fk.Columns, &fk.RefColumns, &fk.OnDelete, &fk.OnUpdate); err != nil {
+			return err
+		}
+
+		if table := tables[tableName]; table != nil {
+			table.ForeignKeys = append(table.ForeignKeys, fk)
+		}
+	}
+
+	return rows.Err()
+}
+
+// fetchChecks loads CHECK constraints, inherited ones are skipped as they're defined by parent tables.
+func fetchChecks(conn *pgx.Conn, tables map[string]*generator.Table) error {
+	ctx := context.Background()
+	rows, err := conn.Query(ctx, `SELECT cl.relname, c.conname, pg_catalog.pg_get_constraintdef(c.oid)
+FROM pg_catalog.pg_constraint c
+JOIN pg_catalog.pg_class cl ON cl.oid = c.conrelid
+JOIN pg_catalog.pg_namespace n ON n.oid = cl.relnamespace
+WHERE c.contype = 'c' AND c.conislocal AND n.nspname = 'public'
+ORDER BY cl.relname, c.conname`)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var tableName string
+		check := &generator.Check{}
+		if err := rows.Scan(&tableName, &check.Name, &check.Definition); err != nil {
+			return err
+		}
+
+		if table := tables[tableName]; table != nil {
+			table.Checks = append(table.Checks, check)
+		}
+	}
+
+	return rows.Err()
+}
+
+// referentialAction returns the SQL expression naming the action of a foreign key, NO ACTION is empty as it's the default.
+func referentialAction(col string) string {
+	return "CASE " + col + ` WHEN 'r' THEN 'RESTRICT' WHEN 'c' THEN 'CASCADE' WHEN 'n' THEN 'SET NULL' WHEN 'd' THEN 'SET DEFAULT' ELSE '' END`
+}
+
+func fetchIndexes(conn *pgx.Conn, tables map[string]*generator.Table) error {
+	ctx := context.Background()
+	rows, err := conn.Query(ctx, `SELECT t.relname, i.relname, ix.indisunique, ix.indisprimary, pg_catalog.pg_get_indexdef(ix.indexrelid),
+  ARRAY(
+    SELECT a.attname::text FROM unnest(ix.indkey::int2[]) WITH ORDINALITY k(attnum, ord)
+    JOIN pg_catalog.pg_attribute a ON a.attrelid = ix.indrelid AND a.attnum = k.attnum ORDER BY k.ord
+  )
+FROM pg_catalog.pg_index ix
+JOIN pg_catalog.pg_class t ON t.oid = ix.indrelid
+JOIN pg_catalog.pg_class i ON i.oid = ix.indexrelid
+JOIN pg_catalog.pg_namespace n ON n.oid = t.relnamespace
+WHERE n.nspname = 'public'
+ORDER BY t.relname, i.relname`)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var tableName string
+		index := &generator.Index{}
+		if err := rows.Scan(&tableName, &index.Name, &index.Unique, &index.Primary, &index.Definition, &index.Columns); err != nil {
+			return err
+		}
+
+		if table := tables[tableName]; table != nil {
+			table.Indexes = append(table.Indexes, index)
+		}
+	}
+
+	return rows.Err()
+}
+
+func (l PostgreSQLLoader) loadQueries(conn *pgx.Conn) ([]*generator.Query, error) {
+	queries, err := ParseQueryFiles(l.QueryFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, q := range queries {
+		if err := describeQuery(conn, q); err != nil {
+			return nil, fmt.Errorf("loader: failed to describe %s: %w", q.Name, err)
+		}
+	}
+
+	return queries, nil
+}
+
+var outerJoin = regexp.MustCompile(`(?i)\b(LEFT|RIGHT|FULL)(\s+OUTER)?\s+JOIN\b`)
+
+// describeQuery uses the prepared statement protocol to fill types of parameters and results of a query.
+func describeQuery(conn *pgx.Conn, q *generator.Query) error {
+	ctx := context.Background()
+	sd, err := conn.Prepare(ctx, "", q.SQL)
+	if err != nil {
+		return err
+	}
+
+	params := make([]*generator.Column, len(sd.ParamOIDs))
+	for i, oid := range sd.ParamOIDs {
+		params[i] = &generator.Column{
+			Name: fmt.Sprintf("arg%d", i+1),
+		}
+		if i < len(q.Params) {
+			params[i].Name = q.Params[i].Name
+		}
+
+		if params[i].DataType, err = formatType(conn, oid); err != nil {
+			return err
+		}
+	}
+	q.Params = params
+
+	// columns of tables are only proven to be non-null without outer joins, which can fill them with NULL
+	hasOuterJoin := outerJoin.MatchString(q.SQL)
+	q.Results = nil
+	seen := map[string]bool{}
+	for _, f := range sd.Fields {
+		if seen[string(f.Name)] {
+			return fmt.Errorf("column %s is returned more than once", f.Name)
+		}
+		seen[string(f.Name)] = true
+
+		col := &generator.Column{
+			Name:     string(f.Name),
+			Nullable: true,
+		}
+
+		if col.DataType, err = formatType(conn, f.DataTypeOID); err != nil {
+			return err
+		}
+
+		if f.TableOID != 0 && !hasOuterJoin {
+			err := conn.QueryRow(
+				ctx,
+				"SELECT NOT attnotnull FROM pg_catalog.pg_attribute WHERE attrelid = $1 AND attnum = $2",
+				f.TableOID,
+				f.TableAttributeNumber,
+			).Scan(&col.Nullable)
+			if err != nil {
+				return err
+			}
+		}
+
+		switch {
+		case containsString(q.Nullable, col.Name):
+			col.Nullable = true
+		case containsString(q.NotNull, col.Name):
+			col.Nullable = false
+		}
+
+		q.Results = append(q.Results, col)
+	}
+
+	for _, name := range append(append([]string{}, q.Nullable...), q.NotNull...) {
+		if !seen[name] {
+			return fmt.Errorf("column %s is declared but not returned", name)
+		}
+	}
+
+	return nil
+}
+
+func containsString(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+func formatType(conn *pgx.Conn, oid uint32) (string, error) {
+	var dataType string
+	err := conn.QueryRow(context.Background(), "SELECT pg_catalog.format_type($1, NULL)", oid).Scan(&dataType)
+	return dataType, err
 }
 
 func fetchColumns(conn *pgx.Conn, tables map[string]*generator.Table) error {
 	ctx := context.Background()
-	rows, err := conn.Query(ctx, "SELECT table_name, column_name, is_nullable, data_type FROM information_schema.columns WHERE table_schema = 'public' ORDER BY column_name")
+	// enums and arrays are named by their types instead of USER-DEFINED and ARRAY
+	rows, err := conn.Query(ctx, `SELECT table_name, column_name, is_nullable,
+  CASE data_type
+    WHEN 'USER-DEFINED' THEN udt_name::text
+    WHEN 'ARRAY' THEN pg_catalog.format_type(format('%I.%I', udt_schema, udt_name)::regtype, NULL)
+    ELSE data_type
+  END,
+  COALESCE(character_maximum_length, 0)::int,
+  CASE WHEN data_type = 'numeric' THEN COALESCE(numeric_precision, 0) ELSE 0 END::int,
+  CASE WHEN data_type = 'numeric' THEN COALESCE(numeric_scale, 0) ELSE 0 END::int,
+  CASE WHEN is_identity = 'YES' THEN identity_generation::text ELSE '' END,
+  COALESCE(column_default, ''),
+  COALESCE(col_description(format('%I.%I', table_schema, table_name)::regclass, ordinal_position), ''),
+  ordinal_position::int
+FROM information_schema.columns WHERE table_schema = 'public' ORDER BY column_name`)
 	if err != nil {
 		return err
 	}
@@ -62,13 +452,16 @@ func fetchColumns(conn *pgx.Conn, tables map[string]*generator.Table) error {
 		column := &generator.Column{}
 		var nullable string
 		var tableName string
-		if err := rows.Scan(&tableName, &column.Name, &nullable, &column.DataType); err != nil {
+		if err := rows.Scan(&tableName, &column.Name, &nullable, &column.DataType, &column.MaxLength,
+			&column.NumericPrecision, &column.NumericScale, &column.Identity, &column.Default, &column.Comment, &column.Position); err != nil {
 			return err
 		}
 
 		column.Nullable = nullable == "YES"
-		tables[tableName].Columns = append(tables[tableName].Columns, column)
+		if table := tables[tableName]; table != nil {
+			table.Columns = append(table.Columns, column)
+		}
 	}
 
-	return nil
+	return rows.Err()
 }
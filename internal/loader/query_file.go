@@ -0,0 +1,296 @@
+package loader
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/bongnv/pggo/internal/generator"
+)
+
+var queryAnnotation = regexp.MustCompile(`^--\s*name:\s*(\w+)\s+:(\w+)\s*$`)
+var nullableAnnotation = regexp.MustCompile(`^--\s*nullable:\s*(\w+(?:\s*,\s*\w+)*)\s*$`)
+
+// ParseQueryFiles reads and parses annotated queries from SQL files.
+// Queries of all files are generated into the same package, so their names must be unique across files.
+func ParseQueryFiles(fileNames []string) ([]*generator.Query, error) {
+	var queries []*generator.Query
+	declaredIn := map[string]string{}
+	generatedFrom := map[string]string{}
+	for _, fileName := range fileNames {
+		content, err := os.ReadFile(fileName)
+		if err != nil {
+			return nil, err
+		}
+
+		fileQueries, err := ParseQueries(fileName, string(content))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, q := range fileQueries {
+			if other, ok := generatedFrom[q.File]; ok && other != fileName {
+				return nil, fmt.Errorf("loader: %s and %s are generated into the same file %s.sql.pggo.go", other, fileName, q.File)
+			}
+			generatedFrom[q.File] = fileName
+
+			if other, ok := declaredIn[q.Name]; ok {
+				return nil, fmt.Errorf("loader: %s: %s is already declared in %s", fileName, q.Name, other)
+			}
+			declaredIn[q.Name] = fileName
+		}
+
+		queries = append(queries, fileQueries...)
+	}
+
+	return queries, nil
+}
+
+// ParseQueries parses annotated queries from the content of a SQL file.
+// Each query starts with an annotation like "-- name: ListUsers :many".
+// Parameters can be named with @name, they are replaced by positional placeholders.
+// Results are nullable unless they're proven otherwise, "-- nullable: a, b" declares nullable results
+// while quoted aliases ending with ! like "total!" declare results which are never NULL.
+func ParseQueries(fileName, content string) ([]*generator.Query, error) {
+	file := strings.TrimSuffix(filepath.Base(fileName), filepath.Ext(fileName))
+
+	var queries []*generator.Query
+	var current *generator.Query
+	body := &strings.Builder{}
+
+	flush := func() error {
+		if current == nil {
+			return nil
+		}
+
+		sql, params, notNull, err := rewriteQuery(strings.TrimSpace(body.String()))
+		if err != nil {
+			return fmt.Errorf("loader: %s: %s: %w", fileName, current.Name, err)
+		}
+
+		if sql == "" {
+			return fmt.Errorf("loader: %s: %s has no SQL", fileName, current.Name)
+		}
+
+		current.SQL = strings.TrimSuffix(sql, ";")
+		current.NotNull = notNull
+		for _, name := range params {
+			current.Params = append(current.Params, &generator.Column{Name: name})
+		}
+		queries = append(queries, current)
+		body.Reset()
+		return nil
+	}
+
+	names := map[string]bool{}
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if m := nullableAnnotation.FindStringSubmatch(strings.TrimSpace(line)); m != nil && current != nil {
+			for _, name := range strings.Split(m[1], ",") {
+				current.Nullable = append(current.Nullable, strings.TrimSpace(name))
+			}
+			continue
+		}
+
+		matches := queryAnnotation.FindStringSubmatch(strings.TrimSpace(line))
+		if matches == nil {
+			if current != nil {
+				_, _ = body.WriteString(line)
+				_, _ = body.WriteString("\n")
+			}
+			continue
+		}
+
+		if err := flush(); err != nil {
+			return nil, err
+		}
+
+		name, command := matches[1], matches[2]
+		switch command {
+		case generator.QueryOne, generator.QueryMany, generator.QueryExec, generator.QueryExecRows:
+		default:
+			return nil, fmt.Errorf("loader: %s: %s has unknown command :%s", fileName, name, command)
+		}
+
+		if names[name] {
+			return nil, fmt.Errorf("loader: %s: %s is declared more than once", fileName, name)
+		}
+		names[name] = true
+
+		current = &generator.Query{
+			Name:    name,
+			Command: command,
+			File:    file,
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	return queries, nil
+}
+
+// rewriteQuery replaces @name parameters by $n placeholders and strips ! from quoted identifiers.
+// It returns the names of parameters in order and names of identifiers ending with !. Positional parameters are named argN.
+// String literals including dollar-quoted ones, other quoted identifiers and comments are left untouched.
+func rewriteQuery(sql string) (string, []string, []string, error) {
+	sb := &strings.Builder{}
+	var names, notNull []string
+	indexes := map[string]int{}
+	positional := 0
+
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+		switch {
+		case c == '\'' || c == '"':
+			// backslashes only escape quotes in escape strings, e.g. E'it\'s'
+			escapes := c == '\'' && i > 0 && (sql[i-1] == 'E' || sql[i-1] == 'e') && (i == 1 || !isIdentChar(sql[i-2]))
+			end := closingQuote(sql, i, escapes)
+			if end < 0 {
+				return "", nil, nil, fmt.Errorf("unterminated quote at %d", i)
+			}
+
+			quoted := sql[i+1 : end]
+			if c == '"' && len(quoted) > 1 && strings.HasSuffix(quoted, "!") {
+				quoted = strings.TrimSuffix(quoted, "!")
+				notNull = append(notNull, strings.ReplaceAll(quoted, `""`, `"`))
+			}
+			_, _ = sb.WriteString(string(c) + quoted + string(c))
+			i = end
+		case c == '-' && strings.HasPrefix(sql[i:], "--"):
+			end := strings.IndexByte(sql[i:], '\n')
+			if end < 0 {
+				end = len(sql) - i
+			}
+			_, _ = sb.WriteString(sql[i : i+end])
+			i += end - 1
+		case c == '/' && strings.HasPrefix(sql[i:], "/*"):
+			end := blockCommentEnd(sql, i)
+			if end < 0 {
+				return "", nil, nil, fmt.Errorf("unterminated comment at %d", i)
+			}
+			_, _ = sb.WriteString(sql[i:end])
+			i = end - 1
+		case c == '$' && (i == 0 || !isIdentChar(sql[i-1])) && dollarTag(sql[i:]) != "":
+			tag := dollarTag(sql[i:])
+			end := strings.Index(sql[i+len(tag):], tag)
+			if end < 0 {
+				return "", nil, nil, fmt.Errorf("unterminated dollar quote at %d", i)
+			}
+			end += i + 2*len(tag)
+			_, _ = sb.WriteString(sql[i:end])
+			i = end - 1
+		case c == '$' && i+1 < len(sql) && isDigit(sql[i+1]) && (i == 0 || !isIdentChar(sql[i-1])):
+			j := i + 1
+			for j < len(sql) && isDigit(sql[j]) {
+				j++
+			}
+			n, _ := strconv.Atoi(sql[i+1 : j])
+			if n > positional {
+				positional = n
+			}
+			_, _ = sb.WriteString(sql[i:j])
+			i = j - 1
+		case c == '@' && i+1 < len(sql) && isIdentStart(sql[i+1]) && (i == 0 || sql[i-1] != '@'):
+			j := i + 1
+			for j < len(sql) && isIdentChar(sql[j]) {
+				j++
+			}
+			name := sql[i+1 : j]
+			if _, ok := indexes[name]; !ok {
+				names = append(names, name)
+				indexes[name] = len(names)
+			}
+			_, _ = sb.WriteString("$" + strconv.Itoa(indexes[name]))
+			i = j - 1
+		default:
+			_ = sb.WriteByte(c)
+		}
+	}
+
+	if positional > 0 && len(names) > 0 {
+		return "", nil, nil, fmt.Errorf("named and positional parameters can't be mixed")
+	}
+
+	for n := 1; n <= positional; n++ {
+		names = append(names, "arg"+strconv.Itoa(n))
+	}
+
+	return sb.String(), names, notNull, nil
+}
+
+// closingQuote returns the index of the quote closing the one at start, or -1 if it isn't closed.
+// Doubled quotes are part of the quoted text rather than closing it.
+func closingQuote(sql string, start int, escapes bool) int {
+	q := sql[start]
+	for i := start + 1; i < len(sql); i++ {
+		switch {
+		case escapes && sql[i] == '\\':
+			i++
+		case sql[i] != q:
+		case i+1 < len(sql) && sql[i+1] == q:
+			i++
+		default:
+			return i
+		}
+	}
+
+	return -1
+}
+
+// blockCommentEnd returns the index after the end of the block comment at start, or -1 if it isn't closed.
+// Block comments can be nested.
+func blockCommentEnd(sql string, start int) int {
+	depth := 0
+	for i := start; i+1 < len(sql); i++ {
+		switch sql[i : i+2] {
+		case "/*":
+			depth++
+			i++
+		case "*/":
+			depth--
+			i++
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+
+	return -1
+}
+
+// dollarTag returns the tag of a dollar quote at the beginning of s, e.g. $$ or $body$, or empty if there isn't any.
+func dollarTag(s string) string {
+	for i := 1; i < len(s); i++ {
+		switch {
+		case s[i] == '$':
+			return s[:i+1]
+		case i == 1 && !isIdentStart(s[i]), !isIdentChar(s[i]):
+			return ""
+		}
+	}
+
+	return ""
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentChar(c byte) bool {
+	return isIdentStart(c) || isDigit(c)
+}
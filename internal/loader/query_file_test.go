@@ -0,0 +1,193 @@
+package loader_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bongnv/pggo/internal/generator"
+	"github.com/bongnv/pggo/internal/loader"
+)
+
+func Test_ParseQueries(t *testing.T) {
+	content := `-- Invoices queries.
+
+-- name: ListOverdueInvoices :many
+SELECT id, amount FROM invoices
+WHERE due_at < @due_before AND status = @status AND paid_at < @due_before;
+
+-- name: GetInvoice :one
+SELECT * FROM invoices WHERE id = $1 AND note <> '@not_a_param' AND attrs @> '{}';
+
+-- name: DeleteInvoices :execrows
+DELETE FROM invoices -- @ignored
+`
+	queries, err := loader.ParseQueries("queries/invoices.sql", content)
+	require.NoError(t, err)
+	require.Equal(t, []*generator.Query{
+		{
+			Name:    "ListOverdueInvoices",
+			Command: generator.QueryMany,
+			File:    "invoices",
+			SQL:     "SELECT id, amount FROM invoices\nWHERE due_at < $1 AND status = $2 AND paid_at < $1",
+			Params: []*generator.Column{
+				{Name: "due_before"},
+				{Name: "status"},
+			},
+		},
+		{
+			Name:    "GetInvoice",
+			Command: generator.QueryOne,
+			File:    "invoices",
+			SQL:     "SELECT * FROM invoices WHERE id = $1 AND note <> '@not_a_param' AND attrs @> '{}'",
+			Params: []*generator.Column{
+				{Name: "arg1"},
+			},
+		},
+		{
+			Name:    "DeleteInvoices",
+			Command: generator.QueryExecRows,
+			File:    "invoices",
+			SQL:     "DELETE FROM invoices -- @ignored",
+		},
+	}, queries)
+}
+
+func Test_ParseQueries_quotes(t *testing.T) {
+	cases := map[string]struct {
+		sql         string
+		expectedSQL string
+		params      []string
+	}{
+		"block comment": {
+			sql:         "SELECT /* @a /* nested @b */ 'it''s' */ name FROM users WHERE id = @id",
+			expectedSQL: "SELECT /* @a /* nested @b */ 'it''s' */ name FROM users WHERE id = $1",
+			params:      []string{"id"},
+		},
+		"dollar quote": {
+			sql:         "SELECT $$ @a 'b $$, $body$ $$ @c $body$, price$1 FROM items WHERE id = @id",
+			expectedSQL: "SELECT $$ @a 'b $$, $body$ $$ @c $body$, price$1 FROM items WHERE id = $1",
+			params:      []string{"id"},
+		},
+		"doubled quotes": {
+			sql:         `SELECT 'it''s @x', "a "" @y" FROM users WHERE name = @name`,
+			expectedSQL: `SELECT 'it''s @x', "a "" @y" FROM users WHERE name = $1`,
+			params:      []string{"name"},
+		},
+		"escape string": {
+			sql:         `SELECT E'it\'s @x', e'\\' FROM users WHERE name = @name`,
+			expectedSQL: `SELECT E'it\'s @x', e'\\' FROM users WHERE name = $1`,
+			params:      []string{"name"},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			queries, err := loader.ParseQueries("a.sql", "-- name: Foo :many\n"+tc.sql+";")
+			require.NoError(t, err)
+			require.Len(t, queries, 1)
+			require.Equal(t, tc.expectedSQL, queries[0].SQL)
+
+			var params []string
+			for _, p := range queries[0].Params {
+				params = append(params, p.Name)
+			}
+			require.Equal(t, tc.params, params)
+		})
+	}
+}
+
+func Test_ParseQueries_nullability(t *testing.T) {
+	content := `-- name: ListUserOrders :many
+-- nullable: name
+SELECT u.name, o.id AS "order_id!", count(*) AS "total!", '"quoted!"' AS note
+FROM users u LEFT JOIN orders o ON o.user_id = u.id
+GROUP BY u.name, o.id;
+`
+	queries, err := loader.ParseQueries("queries/users.sql", content)
+	require.NoError(t, err)
+	require.Equal(t, []*generator.Query{
+		{
+			Name:     "ListUserOrders",
+			Command:  generator.QueryMany,
+			File:     "users",
+			SQL:      "SELECT u.name, o.id AS \"order_id\", count(*) AS \"total\", '\"quoted!\"' AS note\nFROM users u LEFT JOIN orders o ON o.user_id = u.id\nGROUP BY u.name, o.id",
+			Nullable: []string{"name"},
+			NotNull:  []string{"order_id", "total"},
+		},
+	}, queries)
+}
+
+func Test_ParseQueryFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, content string) string {
+		fileName := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(fileName), 0700))
+		require.NoError(t, os.WriteFile(fileName, []byte(content), 0600))
+		return fileName
+	}
+
+	users := writeFile("users.sql", "-- name: GetUser :one\nSELECT 1;")
+	orders := writeFile("orders.sql", "-- name: GetOrder :one\nSELECT 1;")
+	queries, err := loader.ParseQueryFiles([]string{users, orders})
+	require.NoError(t, err)
+	require.Len(t, queries, 2)
+
+	duplicate := writeFile("more/orders.sql", "-- name: GetUser :one\nSELECT 1;")
+	_, err = loader.ParseQueryFiles([]string{users, duplicate})
+	require.EqualError(t, err, "loader: "+duplicate+": GetUser is already declared in "+users)
+
+	_, err = loader.ParseQueryFiles([]string{orders, duplicate})
+	require.EqualError(t, err, "loader: "+orders+" and "+duplicate+" are generated into the same file orders.sql.pggo.go")
+}
+
+func Test_ParseQueries_errors(t *testing.T) {
+	cases := map[string]struct {
+		content     string
+		expectedErr string
+	}{
+		"unknown command": {
+			content:     "-- name: Foo :some\nSELECT 1;",
+			expectedErr: "loader: a.sql: Foo has unknown command :some",
+		},
+		"duplicated name": {
+			content:     "-- name: Foo :one\nSELECT 1;\n-- name: Foo :one\nSELECT 2;",
+			expectedErr: "loader: a.sql: Foo is declared more than once",
+		},
+		"empty query": {
+			content:     "-- name: Foo :one\n\n-- name: Bar :one\nSELECT 2;",
+			expectedErr: "loader: a.sql: Foo has no SQL",
+		},
+		"mixed parameters": {
+			content:     "-- name: Foo :one\nSELECT $1, @name;",
+			expectedErr: "loader: a.sql: Foo: named and positional parameters can't be mixed",
+		},
+		"unterminated quote": {
+			content:     "-- name: Foo :one\nSELECT 'abc;",
+			expectedErr: "loader: a.sql: Foo: unterminated quote at 7",
+		},
+		"unterminated doubled quote": {
+			content:     "-- name: Foo :one\nSELECT 'it''s;",
+			expectedErr: "loader: a.sql: Foo: unterminated quote at 7",
+		},
+		"unterminated comment": {
+			content:     "-- name: Foo :one\nSELECT /* /* */ 1;",
+			expectedErr: "loader: a.sql: Foo: unterminated comment at 7",
+		},
+		"unterminated dollar quote": {
+			content:     "-- name: Foo :one\nSELECT $tag$ abc $$;",
+			expectedErr: "loader: a.sql: Foo: unterminated dollar quote at 7",
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			_, err := loader.ParseQueries("a.sql", tc.content)
+			require.EqualError(t, err, tc.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package loader
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/bongnv/pggo/internal/generator"
+)
+
+// SnapshotLoader is an implementation to load schema from a JSON snapshot created by SnapshotGenerator.
+type SnapshotLoader struct {
+	FileName string
+}
+
+// Load reads the DB schema from the snapshot file.
+func (l SnapshotLoader) Load() (*generator.Schema, error) {
+	content, err := os.ReadFile(l.FileName)
+	if err != nil {
+		return nil, err
+	}
+
+	schema := &generator.Schema{}
+	if err := json.Unmarshal(content, schema); err != nil {
+		return nil, fmt.Errorf("loader: failed to decode snapshot %s: %w", l.FileName, err)
+	}
+
+	return schema, nil
+}
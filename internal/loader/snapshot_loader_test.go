@@ -0,0 +1,36 @@
+package loader_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bongnv/pggo/internal/generator"
+	"github.com/bongnv/pggo/internal/loader"
+)
+
+func Test_SnapshotLoader(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "schema.json")
+	require.NoError(t, os.WriteFile(fileName, []byte(`{"tables": {"users": {"name": "users", "columns": [{"name": "id", "data_type": "bigint"}]}}}`), 0644))
+
+	schema, err := loader.SnapshotLoader{FileName: fileName}.Load()
+	require.NoError(t, err)
+	require.Equal(t, &generator.Schema{
+		Tables: map[string]*generator.Table{
+			"users": {
+				Name:    "users",
+				Columns: []*generator.Column{{Name: "id", DataType: "bigint"}},
+			},
+		},
+	}, schema)
+}
+
+func Test_SnapshotLoader_invalid(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "schema.json")
+	require.NoError(t, os.WriteFile(fileName, []byte(`[]`), 0644))
+
+	_, err := loader.SnapshotLoader{FileName: fileName}.Load()
+	require.Error(t, err)
+}
@@ -1,25 +1,120 @@
 package template
 
 import (
+	"crypto/sha256"
 	"embed"
+	"encoding/hex"
+	"errors"
 	"io"
+	"io/fs"
+	"strings"
 	"text/template"
 )
 
 //go:embed *.tmpl
 var tmplFiles embed.FS
 var rootTemplate = getRootTemplate()
+var fingerprint = getFingerprint()
 
-// Execute executes a template given a name.
+// Execute executes a template given a name. It's safe to be called concurrently.
 func Execute(out io.Writer, name string, data interface{}) error {
 	return rootTemplate.ExecuteTemplate(out, name, data)
 }
 
+// Fingerprint returns a hash of all templates, it changes whenever any template is changed.
+func Fingerprint() string {
+	return fingerprint
+}
+
+var funcs = template.FuncMap{
+	"add":         add,
+	"dict":        dict,
+	"hasPrefix":   strings.HasPrefix,
+	"join":        strings.Join,
+	"lines":       lines,
+	"md":          markdownCell,
+	"mermaidType": mermaidType,
+}
+
 func getRootTemplate() *template.Template {
-	rootTemplate, err := template.ParseFS(tmplFiles, "*")
+	rootTemplate, err := template.New("root").Funcs(funcs).ParseFS(tmplFiles, "*")
 	if err != nil {
 		panic(err)
 	}
 
 	return rootTemplate
 }
+
+func getFingerprint() string {
+	h := sha256.New()
+	// fs.WalkDir visits files in lexical order so the hash is deterministic
+	err := fs.WalkDir(tmplFiles, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		content, err := tmplFiles.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		_, _ = h.Write([]byte(path))
+		_, _ = h.Write(content)
+		return nil
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func add(a, b int) int {
+	return a + b
+}
+
+// dict creates a map from a list of key-value pairs for passing multiple values to a template.
+func dict(pairs ...interface{}) (map[string]interface{}, error) {
+	if len(pairs)%2 != 0 {
+		return nil, errors.New("template: dict requires key-value pairs")
+	}
+
+	m := make(map[string]interface{}, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		key, ok := pairs[i].(string)
+		if !ok {
+			return nil, errors.New("template: dict keys must be strings")
+		}
+		m[key] = pairs[i+1]
+	}
+
+	return m, nil
+}
+
+// lines splits a text into lines, it returns no line if the text is empty.
+func lines(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	return strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+}
+
+var markdownCellReplacer = strings.NewReplacer("|", "\\|", "\r\n", "<br>", "\n", "<br>")
+
+// markdownCell escapes a string to be used in a cell of a Markdown table.
+func markdownCell(s string) string {
+	return markdownCellReplacer.Replace(s)
+}
+
+// mermaidType converts a data type into a single word accepted by Mermaid ER diagrams.
+func mermaidType(dataType string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == '[', r == ']', r == '(', r == ')':
+			return r
+		default:
+			return '_'
+		}
+	}, dataType)
+}
@@ -19,3 +19,8 @@ func TestExecute(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, "This is executed with data: TestExecute.\n", buf.String())
 }
+
+func TestFingerprint(t *testing.T) {
+	require.Len(t, template.Fingerprint(), 64)
+	require.Equal(t, template.Fingerprint(), template.Fingerprint())
+}
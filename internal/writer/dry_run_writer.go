@@ -0,0 +1,79 @@
+package writer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// DryRunWriter reports files that would be created or modified without writing them.
+type DryRunWriter struct {
+	Dir string
+	Out io.Writer
+
+	mu      sync.Mutex
+	written map[string]bool
+}
+
+// Write compares content with the existing file and reports the change if there is any.
+func (w *DryRunWriter) Write(fileName string, content []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.written == nil {
+		w.written = map[string]bool{}
+	}
+	w.written[filepath.ToSlash(fileName)] = true
+
+	existing, err := os.ReadFile(filepath.Join(w.Dir, fileName))
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return w.report("create", fileName)
+	case err != nil:
+		return err
+	case !bytes.Equal(existing, content):
+		return w.report("modify", fileName)
+	default:
+		return nil
+	}
+}
+
+// ReportStale reports generated files in Dir, i.e. *.pggo.go files, which haven't been written.
+// They're left by dropped tables or tables which aren't selected anymore, pggo never deletes them.
+func (w *DryRunWriter) ReportStale() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	err := filepath.WalkDir(w.Dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(d.Name(), ".pggo.go") {
+			return err
+		}
+
+		rel, err := filepath.Rel(w.Dir, path)
+		if err != nil {
+			return err
+		}
+
+		if rel = filepath.ToSlash(rel); w.written[rel] {
+			return nil
+		}
+
+		return w.report("stale", rel)
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
+
+func (w *DryRunWriter) report(action, fileName string) error {
+	_, err := fmt.Fprintf(w.Out, "%s\t%s\n", action, fileName)
+	return err
+}
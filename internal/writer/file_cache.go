@@ -0,0 +1,64 @@
+package writer
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+)
+
+// FileCache stores content hashes of generated tables in a JSON file.
+type FileCache struct {
+	FileName string
+
+	mu     sync.RWMutex
+	hashes map[string]string
+}
+
+// LoadFileCache reads hashes from a file. It returns an empty cache if the file doesn't exist.
+func LoadFileCache(fileName string) (*FileCache, error) {
+	c := &FileCache{
+		FileName: fileName,
+		hashes:   map[string]string{},
+	}
+
+	content, err := os.ReadFile(fileName)
+	if os.IsNotExist(err) {
+		return c, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(content, &c.hashes); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Hash returns the hash of a table generated in the last run, it's empty if the table isn't cached.
+func (c *FileCache) Hash(table string) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.hashes[table]
+}
+
+// SetHash records the hash of a generated table.
+func (c *FileCache) SetHash(table, hash string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.hashes[table] = hash
+}
+
+// Save writes hashes into the file.
+func (c *FileCache) Save() error {
+	c.mu.RLock()
+	content, err := json.MarshalIndent(c.hashes, "", "  ")
+	c.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(c.FileName, append(content, '\n'), 0644)
+}
@@ -10,7 +10,12 @@ type FileWriter struct {
 	Dir string
 }
 
-// Write writes content into a file.
+// Write writes content into a file. Missing parent directories are created.
 func (w FileWriter) Write(fileName string, content []byte) error {
-	return os.WriteFile(path.Join(w.Dir, fileName), content, 0644)
+	filePath := path.Join(w.Dir, fileName)
+	if err := os.MkdirAll(path.Dir(filePath), 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(filePath, content, 0644)
 }
@@ -0,0 +1,27 @@
+package writer
+
+import (
+	"testing/fstest"
+)
+
+// MemoryWriter writes content into an in-memory file system.
+// It's useful to inspect generated files in tests.
+type MemoryWriter struct {
+	FS fstest.MapFS
+}
+
+// NewMemoryWriter creates a new MemoryWriter with an empty file system.
+func NewMemoryWriter() *MemoryWriter {
+	return &MemoryWriter{
+		FS: fstest.MapFS{},
+	}
+}
+
+// Write writes content into a file in the in-memory file system.
+func (w *MemoryWriter) Write(fileName string, content []byte) error {
+	w.FS[fileName] = &fstest.MapFile{
+		Data: append([]byte(nil), content...),
+		Mode: 0644,
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package writer
+
+import (
+	"bytes"
+	"io"
+)
+
+// TxtarWriter streams files as a txtar archive.
+// See https://pkg.go.dev/golang.org/x/tools/txtar for the format.
+type TxtarWriter struct {
+	Out io.Writer
+}
+
+// Write appends a file into the archive.
+func (w TxtarWriter) Write(fileName string, content []byte) error {
+	buf := &bytes.Buffer{}
+	_, _ = buf.WriteString("-- " + fileName + " --\n")
+	_, _ = buf.Write(content)
+	if len(content) > 0 && content[len(content)-1] != '\n' {
+		_ = buf.WriteByte('\n')
+	}
+
+	_, err := w.Out.Write(buf.Bytes())
+	return err
+}
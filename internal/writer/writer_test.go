@@ -0,0 +1,102 @@
+package writer_test
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bongnv/pggo/internal/writer"
+)
+
+func Test_DryRunWriter(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "schema"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "same.pggo.go"), []byte("same"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "changed.pggo.go"), []byte("old"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "schema", "stale.pggo.go"), []byte("stale"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "handwritten.go"), []byte("keep"), 0644))
+
+	out := &bytes.Buffer{}
+	w := &writer.DryRunWriter{
+		Dir: dir,
+		Out: out,
+	}
+
+	require.NoError(t, w.Write("same.pggo.go", []byte("same")))
+	require.NoError(t, w.Write("changed.pggo.go", []byte("new")))
+	require.NoError(t, w.Write("schema/new.pggo.go", []byte("new")))
+	require.Equal(t, "modify\tchanged.pggo.go\ncreate\tschema/new.pggo.go\n", out.String())
+
+	// only generated files are reported, handwritten.go is left out
+	out.Reset()
+	require.NoError(t, w.ReportStale())
+	require.Equal(t, "stale\tschema/stale.pggo.go\n", out.String())
+	require.FileExists(t, filepath.Join(dir, "schema", "stale.pggo.go"))
+
+	content, err := os.ReadFile(filepath.Join(dir, "changed.pggo.go"))
+	require.NoError(t, err)
+	require.Equal(t, "old", string(content))
+}
+
+func Test_DryRunWriter_missing_dir(t *testing.T) {
+	out := &bytes.Buffer{}
+	w := &writer.DryRunWriter{
+		Dir: filepath.Join(t.TempDir(), "missing"),
+		Out: out,
+	}
+
+	require.NoError(t, w.Write("a.pggo.go", []byte("a")))
+	require.NoError(t, w.ReportStale())
+	require.Equal(t, "create\ta.pggo.go\n", out.String())
+}
+
+func Test_TxtarWriter(t *testing.T) {
+	out := &bytes.Buffer{}
+	w := writer.TxtarWriter{
+		Out: out,
+	}
+
+	require.NoError(t, w.Write("a.pggo.go", []byte("package a\n")))
+	require.NoError(t, w.Write("schema/b.pggo.go", []byte("package b")))
+	require.Equal(t, "-- a.pggo.go --\npackage a\n-- schema/b.pggo.go --\npackage b\n", out.String())
+}
+
+func Test_MemoryWriter(t *testing.T) {
+	w := writer.NewMemoryWriter()
+	require.NoError(t, w.Write("schema/a.pggo.go", []byte("package schema\n")))
+
+	content, err := fs.ReadFile(w.FS, "schema/a.pggo.go")
+	require.NoError(t, err)
+	require.Equal(t, "package schema\n", string(content))
+}
+
+func Test_FileWriter(t *testing.T) {
+	dir := t.TempDir()
+	w := writer.FileWriter{
+		Dir: dir,
+	}
+
+	require.NoError(t, w.Write("graphql/users.graphql", []byte("type User")))
+	content, err := os.ReadFile(filepath.Join(dir, "graphql", "users.graphql"))
+	require.NoError(t, err)
+	require.Equal(t, "type User", string(content))
+}
+
+func Test_FileCache(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "cache.json")
+	c, err := writer.LoadFileCache(fileName)
+	require.NoError(t, err)
+	require.Empty(t, c.Hash("users"))
+
+	c.SetHash("users", "abc")
+	require.Equal(t, "abc", c.Hash("users"))
+	require.NoError(t, c.Save())
+
+	c, err = writer.LoadFileCache(fileName)
+	require.NoError(t, err)
+	require.Equal(t, "abc", c.Hash("users"))
+}
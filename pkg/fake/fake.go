@@ -0,0 +1,144 @@
+// Package fake provides deterministic fake values used by generated test data factories.
+// Values are derived from a sequence number, so the same sequence always produces the same values.
+package fake
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// BaseTime is the time which fake times are derived from.
+var BaseTime = time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// seq starts from the current time in milliseconds, it's kept below 1e9 so that numbers fit in integer columns.
+var seq = time.Now().UnixNano() / int64(time.Millisecond) % 1e9
+
+// Seq returns the next number of a sequence shared by all factories. Numbers are unique within a process
+// and unlikely to repeat across runs, so factories use them for keys without conflicting with existing rows.
+// Unlike other fake values, they aren't deterministic.
+func Seq() int64 {
+	return atomic.AddInt64(&seq, 1)
+}
+
+// maxHours and maxDays keep fake times within 100 years after BaseTime, which Postgres and time.Duration can hold.
+const (
+	maxHours = 100 * 365 * 24
+	maxDays  = 100 * 365
+)
+
+// Int16 returns a fake smallint value between 1 and math.MaxInt16, n wraps around.
+func Int16(n int64) int16 {
+	return int16(wrap(n, math.MaxInt16))
+}
+
+// Int32 returns a fake integer value between 1 and math.MaxInt32, n wraps around.
+func Int32(n int64) int32 {
+	return int32(wrap(n, math.MaxInt32))
+}
+
+// Numeric returns a fake value of a numeric(precision, scale) column, n wraps around the largest integer part.
+// If there is no integer part, n is used as the fraction.
+func Numeric(n int64, precision, scale int) float64 {
+	digits := precision - scale
+	switch {
+	case digits <= 0:
+		limit := pow10(scale)
+		return float64(wrap(n, limit-1)) / float64(limit)
+	case digits >= 18:
+		return float64(n)
+	default:
+		return float64(wrap(n, pow10(digits)-1))
+	}
+}
+
+// Duration returns a fake interval which is n minutes, n wraps around 100 years.
+func Duration(n int64) time.Duration {
+	return time.Duration(wrap(n, maxHours*60)) * time.Minute
+}
+
+// wrap maps n into [1, max] so that sequence numbers fit into limited ranges.
+func wrap(n, max int64) int64 {
+	if n < 1 {
+		n = -n + 1
+	}
+
+	return (n-1)%max + 1
+}
+
+func pow10(n int) int64 {
+	if n > 18 {
+		n = 18
+	}
+
+	v := int64(1)
+	for i := 0; i < n; i++ {
+		v *= 10
+	}
+
+	return v
+}
+
+// String returns a fake value for a text column. Common column names like email, url or phone
+// get values in the matching formats. The value is truncated to maxLength if it's positive.
+func String(column string, n int64, maxLength int) string {
+	name := strings.ToLower(column)
+	var s string
+	switch {
+	case strings.Contains(name, "email"):
+		s = fmt.Sprintf("user%d@example.com", n)
+	case strings.Contains(name, "url") || strings.Contains(name, "website"):
+		s = fmt.Sprintf("https://example.com/%d", n)
+	case strings.Contains(name, "phone"):
+		s = fmt.Sprintf("+1555%07d", n%10000000)
+	default:
+		s = fmt.Sprintf("%s %d", column, n)
+	}
+
+	if maxLength > 0 && len(s) > maxLength {
+		// keep the end of the value as it contains the sequence number
+		s = s[len(s)-maxLength:]
+	}
+
+	return s
+}
+
+// Time returns a fake timestamp which is n hours after BaseTime, n wraps around 100 years.
+func Time(n int64) time.Time {
+	return BaseTime.Add(time.Duration(wrap(n, maxHours)) * time.Hour)
+}
+
+// Date returns a fake date which is n days after BaseTime, n wraps around 100 years.
+func Date(n int64) time.Time {
+	return BaseTime.AddDate(0, 0, int(wrap(n, maxDays)))
+}
+
+// UUID returns a fake name-based UUID (version 5) derived from the seed and n.
+// It returns an array so that it can be converted to any UUID type.
+func UUID(seed string, n int64) [16]byte {
+	sum := sha1.Sum([]byte(fmt.Sprintf("%s-%d", seed, n)))
+
+	var id [16]byte
+	copy(id[:], sum[:16])
+	id[6] = (id[6] & 0x0f) | 0x50
+	id[8] = (id[8] & 0x3f) | 0x80
+	return id
+}
+
+// Enum returns a value of an enum given the sequence number.
+func Enum(n int64, values ...string) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[int((n-1)%int64(len(values)))]
+}
+
+// IsZero reports whether v is the zero value of its type, e.g. a foreign key that hasn't been set.
+func IsZero(v interface{}) bool {
+	return v == nil || reflect.ValueOf(v).IsZero()
+}
@@ -0,0 +1,74 @@
+package fake_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bongnv/pggo/pkg/fake"
+)
+
+func Test_String(t *testing.T) {
+	require.Equal(t, "name 1", fake.String("name", 1, 0))
+	require.Equal(t, "user2@example.com", fake.String("contact_email", 2, 0))
+	require.Equal(t, "https://example.com/3", fake.String("avatar_url", 3, 0))
+	require.Equal(t, "+15550000004", fake.String("phone", 4, 0))
+	require.Equal(t, "e 12", fake.String("title", 12, 4))
+}
+
+func Test_Seq(t *testing.T) {
+	n := fake.Seq()
+	require.Positive(t, n)
+	require.Less(t, n, int64(math.MaxInt32))
+	require.Equal(t, n+1, fake.Seq())
+}
+
+func Test_Time(t *testing.T) {
+	require.Equal(t, time.Date(2021, time.January, 1, 2, 0, 0, 0, time.UTC), fake.Time(2))
+	require.Equal(t, time.Date(2021, time.January, 3, 0, 0, 0, 0, time.UTC), fake.Date(2))
+	require.True(t, fake.Time(1e9).Before(fake.BaseTime.AddDate(101, 0, 0)))
+	require.True(t, fake.Date(1e9).Before(fake.BaseTime.AddDate(101, 0, 0)))
+	require.Equal(t, 3*time.Minute, fake.Duration(3))
+	require.Positive(t, fake.Duration(math.MaxInt64))
+}
+
+func Test_Int(t *testing.T) {
+	require.Equal(t, int16(2), fake.Int16(2))
+	require.Equal(t, int16(1), fake.Int16(math.MaxInt16+1))
+	require.Equal(t, int32(1e9), fake.Int32(1e9))
+	require.Equal(t, int32(1), fake.Int32(math.MaxInt32+1))
+	require.Positive(t, fake.Int32(-5))
+}
+
+func Test_Numeric(t *testing.T) {
+	require.Equal(t, float64(12), fake.Numeric(12, 5, 2))
+	require.Equal(t, float64(1), fake.Numeric(1000, 5, 2))
+	require.Equal(t, 0.05, fake.Numeric(5, 2, 2))
+	require.Equal(t, float64(1e9), fake.Numeric(1e9, 20, 0))
+}
+
+func Test_UUID(t *testing.T) {
+	id := fake.UUID("users.id", 1)
+	require.Equal(t, id, fake.UUID("users.id", 1))
+	require.NotEqual(t, id, fake.UUID("users.id", 2))
+	require.Equal(t, byte(0x50), id[6]&0xf0)
+	require.Equal(t, byte(0x80), id[8]&0xc0)
+}
+
+func Test_Enum(t *testing.T) {
+	require.Equal(t, "a", fake.Enum(1, "a", "b"))
+	require.Equal(t, "b", fake.Enum(2, "a", "b"))
+	require.Equal(t, "a", fake.Enum(3, "a", "b"))
+	require.Equal(t, "", fake.Enum(1))
+}
+
+func Test_IsZero(t *testing.T) {
+	require.True(t, fake.IsZero(nil))
+	require.True(t, fake.IsZero(int64(0)))
+	require.True(t, fake.IsZero([16]byte{}))
+	require.True(t, fake.IsZero(time.Time{}))
+	require.False(t, fake.IsZero("a"))
+	require.False(t, fake.IsZero(fake.UUID("id", 1)))
+}
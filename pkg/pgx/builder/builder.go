@@ -25,6 +25,12 @@ func With(conn Conn) sqlb.Factory {
 	}
 }
 
+// VerifySchema compares tables with the live DB schema, see sqlb.VerifySchema.
+// Generated packages list their tables in TableDefinitions.
+func VerifySchema(ctx context.Context, conn Conn, tables ...*sqlb.TableDefinition) error {
+	return sqlb.VerifySchema(ctx, With(conn).DB, tables...)
+}
+
 type pgxDB struct {
 	conn Conn
 }
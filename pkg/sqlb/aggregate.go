@@ -0,0 +1,130 @@
+package sqlb
+
+import (
+	"errors"
+	"io"
+)
+
+// Aggregate is an aggregate function call. It's an Expression, so it can be selected via SelectExpr,
+// compared in HAVING via Compare and sorted via AscExpr or DescExpr.
+type Aggregate struct {
+	fn       string
+	distinct bool
+	arg      string
+	filter   Condition
+}
+
+// Count creates a COUNT aggregate, use * to count all rows.
+func Count(col string) Aggregate {
+	return Aggregate{fn: "COUNT", arg: col}
+}
+
+// CountDistinct creates a COUNT(DISTINCT col) aggregate.
+func CountDistinct(col string) Aggregate {
+	return Aggregate{fn: "COUNT", arg: col, distinct: true}
+}
+
+// Sum creates a SUM aggregate.
+func Sum(col string) Aggregate {
+	return Aggregate{fn: "SUM", arg: col}
+}
+
+// Avg creates an AVG aggregate.
+func Avg(col string) Aggregate {
+	return Aggregate{fn: "AVG", arg: col}
+}
+
+// Min creates a MIN aggregate.
+func Min(col string) Aggregate {
+	return Aggregate{fn: "MIN", arg: col}
+}
+
+// Max creates a MAX aggregate.
+func Max(col string) Aggregate {
+	return Aggregate{fn: "MAX", arg: col}
+}
+
+// ArrayAgg creates an ARRAY_AGG aggregate.
+func ArrayAgg(col string) Aggregate {
+	return Aggregate{fn: "ARRAY_AGG", arg: col}
+}
+
+// JSONAgg creates a JSON_AGG aggregate.
+func JSONAgg(col string) Aggregate {
+	return Aggregate{fn: "JSON_AGG", arg: col}
+}
+
+// Filter adds a FILTER (WHERE ...) clause, so that only matching rows are aggregated.
+func (a Aggregate) Filter(conds ...Condition) Aggregate {
+	a.filter = And(conds...)
+	return a
+}
+
+// Build adds the aggregate function call to the SQL query.
+func (a Aggregate) Build(sw io.StringWriter, aa Placeholders) error {
+	if a.fn == "" || a.arg == "" {
+		return errors.New("sqlb: aggregate must have a function and a column")
+	}
+
+	_, _ = sw.WriteString(a.fn)
+	_, _ = sw.WriteString("(")
+	if a.distinct {
+		_, _ = sw.WriteString("DISTINCT ")
+	}
+	_, _ = sw.WriteString(a.arg)
+	_, _ = sw.WriteString(")")
+
+	if a.filter != nil {
+		_, _ = sw.WriteString(" FILTER (WHERE ")
+		if err := a.filter.Build(sw, aa); err != nil {
+			return err
+		}
+		_, _ = sw.WriteString(")")
+	}
+
+	return nil
+}
+
+func (a Aggregate) expressionOnly() {}
+
+// selectExpr is an expression in the select list with an optional alias.
+type selectExpr struct {
+	expr  Expression
+	alias string
+}
+
+func (e selectExpr) Build(sw io.StringWriter, aa Placeholders) error {
+	if err := e.expr.Build(sw, aa); err != nil {
+		return err
+	}
+
+	if e.alias != "" {
+		_, _ = sw.WriteString(" AS ")
+		_, _ = sw.WriteString(e.alias)
+	}
+
+	return nil
+}
+
+type groupByClause []string
+
+func (c groupByClause) Build(sw io.StringWriter, _ Placeholders) error {
+	for i, col := range c {
+		if i == 0 {
+			_, _ = sw.WriteString(" GROUP BY ")
+		} else {
+			_, _ = sw.WriteString(", ")
+		}
+		_, _ = sw.WriteString(col)
+	}
+	return nil
+}
+
+type havingClause struct {
+	cond Condition
+}
+
+func (h havingClause) Build(sb io.StringWriter, aa Placeholders) error {
+	_, _ = sb.WriteString(" HAVING ")
+	return h.cond.Build(sb, aa)
+}
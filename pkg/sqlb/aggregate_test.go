@@ -0,0 +1,92 @@
+package sqlb_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bongnv/pggo/pkg/sqlb"
+)
+
+func Test_Aggregates(t *testing.T) {
+	testCases := map[string]struct {
+		aggregate   sqlb.Aggregate
+		expectedSQL string
+	}{
+		"count":          {aggregate: sqlb.Count("*"), expectedSQL: "SELECT COUNT(*)"},
+		"count distinct": {aggregate: sqlb.CountDistinct("user_id"), expectedSQL: "SELECT COUNT(DISTINCT user_id)"},
+		"sum":            {aggregate: sqlb.Sum("total"), expectedSQL: "SELECT SUM(total)"},
+		"avg":            {aggregate: sqlb.Avg("total"), expectedSQL: "SELECT AVG(total)"},
+		"min":            {aggregate: sqlb.Min("total"), expectedSQL: "SELECT MIN(total)"},
+		"max":            {aggregate: sqlb.Max("total"), expectedSQL: "SELECT MAX(total)"},
+		"array_agg":      {aggregate: sqlb.ArrayAgg("id"), expectedSQL: "SELECT ARRAY_AGG(id)"},
+		"json_agg":       {aggregate: sqlb.JSONAgg("tags"), expectedSQL: "SELECT JSON_AGG(tags)"},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			sql, args, err := sqlb.Select().SelectExpr(tc.aggregate, "").SQL()
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedSQL, sql)
+			require.Empty(t, args)
+		})
+	}
+
+	t.Run("empty aggregate", func(t *testing.T) {
+		_, _, err := sqlb.Select().SelectExpr(sqlb.Aggregate{}, "").SQL()
+		require.EqualError(t, err, "sqlb: aggregate must have a function and a column")
+	})
+}
+
+func Test_SelectBuilder_GroupBy(t *testing.T) {
+	t.Run("with aliases and filter", func(t *testing.T) {
+		sql, args, err := sqlb.Select("user_id").
+			SelectExpr(sqlb.Count("*"), "total").
+			SelectExpr(sqlb.Sum("amount").Filter(sqlb.Equal("status", "paid")), "paid").
+			FromTable("orders").
+			Where(sqlb.Equal("shop_id", 7)).
+			GroupBy("user_id").
+			Having(sqlb.Compare(sqlb.Count("*"), ">", 5)).
+			OrderBy(sqlb.DescExpr(sqlb.Count("*")), sqlb.Asc("user_id")).
+			Limit(10).
+			SQL()
+		require.NoError(t, err)
+		require.Equal(t, "SELECT user_id, COUNT(*) AS total, SUM(amount) FILTER (WHERE (status = $1)) AS paid FROM orders "+
+			"WHERE (shop_id = $2) GROUP BY user_id HAVING (COUNT(*) > $3) ORDER BY COUNT(*) DESC, user_id ASC LIMIT $4", sql)
+		require.Equal(t, []interface{}{"paid", 7, 5, 10}, args)
+	})
+
+	t.Run("multiple columns and conditions", func(t *testing.T) {
+		sql, args, err := sqlb.Select("shop_id", "status").
+			SelectExpr(sqlb.CountDistinct("user_id"), "users").
+			FromTable("orders").
+			GroupBy("shop_id").
+			GroupBy("status").
+			Having(sqlb.Compare(sqlb.Min("amount"), ">=", 1), sqlb.Compare(sqlb.Max("amount"), "<", 100)).
+			SQL()
+		require.NoError(t, err)
+		require.Equal(t, "SELECT shop_id, status, COUNT(DISTINCT user_id) AS users FROM orders "+
+			"GROUP BY shop_id, status HAVING ((MIN(amount) >= $1) AND (MAX(amount) < $2))", sql)
+		require.Equal(t, []interface{}{1, 100}, args)
+	})
+
+	t.Run("unsupported operator", func(t *testing.T) {
+		_, _, err := sqlb.Select("user_id").
+			FromTable("orders").
+			GroupBy("user_id").
+			Having(sqlb.Compare(sqlb.Count("*"), "; DROP TABLE orders; --", 1)).
+			SQL()
+		require.EqualError(t, err, "sqlb: unsupported comparison operator ; DROP TABLE orders; --")
+	})
+
+	t.Run("filter with error", func(t *testing.T) {
+		_, _, err := sqlb.Select().SelectExpr(sqlb.Count("*").Filter(sqlb.In("id")), "total").FromTable("orders").SQL()
+		require.EqualError(t, err, "values list must not be empty")
+	})
+
+	t.Run("having with error", func(t *testing.T) {
+		_, _, err := sqlb.Select("user_id").FromTable("orders").GroupBy("user_id").Having(sqlb.In("user_id")).SQL()
+		require.EqualError(t, err, "values list must not be empty")
+	})
+}
@@ -19,10 +19,27 @@ type Condition interface {
 
 // Equal creates an = condition.
 func Equal(column string, value interface{}) Condition {
+	return compare(column, "=", value)
+}
+
+// EqualColumn creates an = condition between two columns, e.g. to join tables.
+func EqualColumn(column, other string) Condition {
 	return binaryCond{
 		operator: "=",
-		col:      column,
-		value:    placeholder{value: value},
+		col:      columnRef(column),
+		value:    columnRef(other),
+	}
+}
+
+// Compare creates a condition comparing an expression with a value, e.g. an aggregate in a HAVING clause.
+// The operator must be one of =, <>, <, <=, > and >=.
+func Compare(expr Expression, operator string, value interface{}) Condition {
+	return compareCond{
+		binaryCond: binaryCond{
+			operator: operator,
+			col:      expr,
+			value:    placeholder{value: value},
+		},
 	}
 }
 
@@ -30,11 +47,109 @@ func Equal(column string, value interface{}) Condition {
 func In(column string, values ...interface{}) Condition {
 	return binaryCond{
 		operator: "IN",
-		col:      column,
+		col:      columnRef(column),
 		value:    groupPlaceholder{values: values},
 	}
 }
 
+// NotIn creates a NOT IN condition.
+func NotIn(column string, values ...interface{}) Condition {
+	return binaryCond{
+		operator: "NOT IN",
+		col:      columnRef(column),
+		value:    groupPlaceholder{values: values},
+	}
+}
+
+// NotEqual creates a <> condition.
+func NotEqual(column string, value interface{}) Condition {
+	return compare(column, "<>", value)
+}
+
+// Lt creates a < condition.
+func Lt(column string, value interface{}) Condition {
+	return compare(column, "<", value)
+}
+
+// Lte creates a <= condition.
+func Lte(column string, value interface{}) Condition {
+	return compare(column, "<=", value)
+}
+
+// Gt creates a > condition.
+func Gt(column string, value interface{}) Condition {
+	return compare(column, ">", value)
+}
+
+// Gte creates a >= condition.
+func Gte(column string, value interface{}) Condition {
+	return compare(column, ">=", value)
+}
+
+// Between creates a BETWEEN condition, both bounds are inclusive.
+func Between(column string, low, high interface{}) Condition {
+	return binaryCond{
+		operator: "BETWEEN",
+		col:      columnRef(column),
+		value:    betweenPlaceholder{low: low, high: high},
+	}
+}
+
+// Like creates a LIKE condition.
+func Like(column string, pattern interface{}) Condition {
+	return compare(column, "LIKE", pattern)
+}
+
+// ILike creates an ILIKE condition which matches case-insensitively.
+func ILike(column string, pattern interface{}) Condition {
+	return compare(column, "ILIKE", pattern)
+}
+
+// SimilarTo creates a SIMILAR TO condition.
+func SimilarTo(column string, pattern interface{}) Condition {
+	return compare(column, "SIMILAR TO", pattern)
+}
+
+// Regex creates a ~ condition matching a POSIX regular expression.
+func Regex(column string, pattern interface{}) Condition {
+	return compare(column, "~", pattern)
+}
+
+// IRegex creates a ~* condition matching a POSIX regular expression case-insensitively.
+func IRegex(column string, pattern interface{}) Condition {
+	return compare(column, "~*", pattern)
+}
+
+// IsNull creates an IS NULL condition.
+func IsNull(column string) Condition {
+	return binaryCond{
+		operator: "IS",
+		col:      columnRef(column),
+		value:    rawExpr("NULL"),
+	}
+}
+
+// IsNotNull creates an IS NOT NULL condition.
+func IsNotNull(column string) Condition {
+	return binaryCond{
+		operator: "IS NOT",
+		col:      columnRef(column),
+		value:    rawExpr("NULL"),
+	}
+}
+
+// IsDistinctFrom creates an IS DISTINCT FROM condition, which treats NULL as a comparable value.
+func IsDistinctFrom(column string, value interface{}) Condition {
+	return compare(column, "IS DISTINCT FROM", value)
+}
+
+// Not negates a condition.
+func Not(cond Condition) Condition {
+	return notCond{
+		cond: cond,
+	}
+}
+
 // And creates an AND condition.
 func And(conds ...Condition) Condition {
 	return logicalCond{
@@ -51,6 +166,16 @@ func Or(conds ...Condition) Condition {
 	}
 }
 
+// comparisonOperators are operators allowed by Compare.
+var comparisonOperators = map[string]bool{
+	"=":  true,
+	"<>": true,
+	"<":  true,
+	"<=": true,
+	">":  true,
+	">=": true,
+}
+
 type baseCond struct{}
 
 func (c baseCond) conditionOnly() {}
@@ -58,13 +183,15 @@ func (c baseCond) conditionOnly() {}
 type binaryCond struct {
 	baseCond
 	operator string
-	col      string
+	col      Builder
 	value    Builder
 }
 
 func (c binaryCond) Build(sw io.StringWriter, aa Placeholders) error {
 	_, _ = sw.WriteString("(")
-	_, _ = sw.WriteString(c.col)
+	if err := c.col.Build(sw, aa); err != nil {
+		return err
+	}
 	_, _ = sw.WriteString(" ")
 	_, _ = sw.WriteString(c.operator)
 	_, _ = sw.WriteString(" ")
@@ -75,15 +202,103 @@ func (c binaryCond) Build(sw io.StringWriter, aa Placeholders) error {
 	return nil
 }
 
+// compare creates a condition comparing a column with a value passed as an argument.
+func compare(column, operator string, value interface{}) Condition {
+	return binaryCond{
+		operator: operator,
+		col:      columnRef(column),
+		value:    placeholder{value: value},
+	}
+}
+
+type notCond struct {
+	baseCond
+	cond Condition
+}
+
+func (c notCond) Build(sw io.StringWriter, aa Placeholders) error {
+	if c.cond == nil {
+		return errors.New("sqlb: NOT requires a condition")
+	}
+
+	_, _ = sw.WriteString("(NOT ")
+	if err := c.cond.Build(sw, aa); err != nil {
+		return err
+	}
+	_, _ = sw.WriteString(")")
+	return nil
+}
+
+// compareCond is a binaryCond whose operator is provided by users, so it's validated.
+type compareCond struct {
+	binaryCond
+}
+
+func (c compareCond) Build(sw io.StringWriter, aa Placeholders) error {
+	if !comparisonOperators[c.operator] {
+		return errors.New("sqlb: unsupported comparison operator " + c.operator)
+	}
+
+	return c.binaryCond.Build(sw, aa)
+}
+
+// Expression is a SQL expression which is written into the query instead of being passed as an argument.
+type Expression interface {
+	Builder
+	expressionOnly()
+}
+
+// Expr creates a raw SQL expression, e.g. lower(name) or now(). It's written into the query as is,
+// so it must not contain user input.
+func Expr(sql string) Expression {
+	return rawExpr(sql)
+}
+
+type rawExpr string
+
+func (e rawExpr) Build(sw io.StringWriter, _ Placeholders) error {
+	_, _ = sw.WriteString(string(e))
+	return nil
+}
+
+func (e rawExpr) expressionOnly() {}
+
+// Excluded refers to the value of a column proposed for insertion in ON CONFLICT DO UPDATE, i.e. EXCLUDED.col.
+func Excluded(col string) Expression {
+	return excludedColumn(col)
+}
+
+type excludedColumn string
+
+func (c excludedColumn) Build(sw io.StringWriter, _ Placeholders) error {
+	_, _ = sw.WriteString("EXCLUDED.")
+	_, _ = sw.WriteString(string(c))
+	return nil
+}
+
+func (c excludedColumn) expressionOnly() {}
+
 type placeholder struct {
 	value interface{}
 }
 
 func (p placeholder) Build(sw io.StringWriter, aa Placeholders) error {
+	if expr, ok := p.value.(Expression); ok {
+		return expr.Build(sw, aa)
+	}
+
 	_, _ = sw.WriteString(aa.Append(p.value))
 	return nil
 }
 
+// columnRef is a column used in the select list, in sort expressions or as an operand of a condition.
+type columnRef string
+
+func (c columnRef) Build(sw io.StringWriter, _ Placeholders) error {
+	_, _ = sw.WriteString(string(c))
+	return nil
+}
+
 type groupPlaceholder struct {
 	values []interface{}
 }
@@ -105,6 +320,19 @@ func (g groupPlaceholder) Build(sw io.StringWriter, aa Placeholders) error {
 	return nil
 }
 
+type betweenPlaceholder struct {
+	low  interface{}
+	high interface{}
+}
+
+func (b betweenPlaceholder) Build(sw io.StringWriter, aa Placeholders) error {
+	if err := (placeholder{value: b.low}).Build(sw, aa); err != nil {
+		return err
+	}
+	_, _ = sw.WriteString(" AND ")
+	return placeholder{value: b.high}.Build(sw, aa)
+}
+
 type logicalCond struct {
 	baseCond
 	operator string
@@ -23,6 +23,18 @@ func Test_Conditions(t *testing.T) {
 			expectedQuery: "(id = $1)",
 			expectedArgs:  []interface{}{10},
 		},
+		"equal column": {
+			createCond: func() sqlb.Condition {
+				return sqlb.EqualColumn("users.org_id", "orgs.id")
+			},
+			expectedQuery: "(users.org_id = orgs.id)",
+		},
+		"equal excluded": {
+			createCond: func() sqlb.Condition {
+				return sqlb.Equal("event.name", sqlb.Excluded("name"))
+			},
+			expectedQuery: "(event.name = EXCLUDED.name)",
+		},
 		"in": {
 			createCond: func() sqlb.Condition {
 				return sqlb.In("id", 1, 2, 3, 4)
@@ -30,6 +42,140 @@ func Test_Conditions(t *testing.T) {
 			expectedQuery: "(id IN ($1,$2,$3,$4))",
 			expectedArgs:  []interface{}{1, 2, 3, 4},
 		},
+		"not equal": {
+			createCond: func() sqlb.Condition {
+				return sqlb.NotEqual("status", "deleted")
+			},
+			expectedQuery: "(status <> $1)",
+			expectedArgs:  []interface{}{"deleted"},
+		},
+		"lt": {
+			createCond: func() sqlb.Condition {
+				return sqlb.Lt("age", 18)
+			},
+			expectedQuery: "(age < $1)",
+			expectedArgs:  []interface{}{18},
+		},
+		"lte": {
+			createCond: func() sqlb.Condition {
+				return sqlb.Lte("age", 18)
+			},
+			expectedQuery: "(age <= $1)",
+			expectedArgs:  []interface{}{18},
+		},
+		"gt": {
+			createCond: func() sqlb.Condition {
+				return sqlb.Gt("age", 65)
+			},
+			expectedQuery: "(age > $1)",
+			expectedArgs:  []interface{}{65},
+		},
+		"gte": {
+			createCond: func() sqlb.Condition {
+				return sqlb.Gte("age", 65)
+			},
+			expectedQuery: "(age >= $1)",
+			expectedArgs:  []interface{}{65},
+		},
+		"between": {
+			createCond: func() sqlb.Condition {
+				return sqlb.Between("age", 18, 65)
+			},
+			expectedQuery: "(age BETWEEN $1 AND $2)",
+			expectedArgs:  []interface{}{18, 65},
+		},
+		"not in": {
+			createCond: func() sqlb.Condition {
+				return sqlb.NotIn("id", 1, 2)
+			},
+			expectedQuery: "(id NOT IN ($1,$2))",
+			expectedArgs:  []interface{}{1, 2},
+		},
+		"like": {
+			createCond: func() sqlb.Condition {
+				return sqlb.Like("name", "Jo%")
+			},
+			expectedQuery: "(name LIKE $1)",
+			expectedArgs:  []interface{}{"Jo%"},
+		},
+		"ilike": {
+			createCond: func() sqlb.Condition {
+				return sqlb.ILike("name", "jo%")
+			},
+			expectedQuery: "(name ILIKE $1)",
+			expectedArgs:  []interface{}{"jo%"},
+		},
+		"similar to": {
+			createCond: func() sqlb.Condition {
+				return sqlb.SimilarTo("name", "(Jo|Ja)%")
+			},
+			expectedQuery: "(name SIMILAR TO $1)",
+			expectedArgs:  []interface{}{"(Jo|Ja)%"},
+		},
+		"regex": {
+			createCond: func() sqlb.Condition {
+				return sqlb.Regex("name", "^Jo")
+			},
+			expectedQuery: "(name ~ $1)",
+			expectedArgs:  []interface{}{"^Jo"},
+		},
+		"case-insensitive regex": {
+			createCond: func() sqlb.Condition {
+				return sqlb.IRegex("name", "^jo")
+			},
+			expectedQuery: "(name ~* $1)",
+			expectedArgs:  []interface{}{"^jo"},
+		},
+		"is null": {
+			createCond: func() sqlb.Condition {
+				return sqlb.IsNull("deleted_at")
+			},
+			expectedQuery: "(deleted_at IS NULL)",
+		},
+		"is not null": {
+			createCond: func() sqlb.Condition {
+				return sqlb.IsNotNull("deleted_at")
+			},
+			expectedQuery: "(deleted_at IS NOT NULL)",
+		},
+		"is distinct from": {
+			createCond: func() sqlb.Condition {
+				return sqlb.IsDistinctFrom("manager_id", 10)
+			},
+			expectedQuery: "(manager_id IS DISTINCT FROM $1)",
+			expectedArgs:  []interface{}{10},
+		},
+		"not": {
+			createCond: func() sqlb.Condition {
+				return sqlb.Not(sqlb.Or(sqlb.Equal("id", 10), sqlb.IsNull("name")))
+			},
+			expectedQuery: "(NOT ((id = $1) OR (name IS NULL)))",
+			expectedArgs:  []interface{}{10},
+		},
+		"between columns": {
+			createCond: func() sqlb.Condition {
+				return sqlb.Between("now", sqlb.Excluded("starts_at"), sqlb.Excluded("ends_at"))
+			},
+			expectedQuery: "(now BETWEEN EXCLUDED.starts_at AND EXCLUDED.ends_at)",
+		},
+		"empty not in": {
+			createCond: func() sqlb.Condition {
+				return sqlb.NotIn("id")
+			},
+			expectedErr: "values list must not be empty",
+		},
+		"not without condition": {
+			createCond: func() sqlb.Condition {
+				return sqlb.Not(nil)
+			},
+			expectedErr: "sqlb: NOT requires a condition",
+		},
+		"not with error": {
+			createCond: func() sqlb.Condition {
+				return sqlb.Not(sqlb.In("id"))
+			},
+			expectedErr: "values list must not be empty",
+		},
 		"and": {
 			createCond: func() sqlb.Condition {
 				cond1 := sqlb.Equal("id", 10)
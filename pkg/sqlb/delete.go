@@ -0,0 +1,124 @@
+package sqlb
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// DeleteBuilder is a builder to build a DELETE query.
+type DeleteBuilder struct {
+	table        Table
+	using        []Table
+	where        Builder
+	all          bool
+	returning    returningClause
+	db           Execer
+	affectedRows *int64
+}
+
+// UsingTable adds tables to the USING clause, so that columns of other tables can be used in the query.
+func (b *DeleteBuilder) UsingTable(tables ...string) *DeleteBuilder {
+	for _, t := range tables {
+		b.Using(BaseTable(t))
+	}
+	return b
+}
+
+// Using adds tables to the USING clause, so that columns of other tables can be used in the query.
+func (b *DeleteBuilder) Using(tables ...Table) *DeleteBuilder {
+	b.using = append(b.using, tables...)
+	return b
+}
+
+// Where sets the WHERE clause for the query.
+func (b *DeleteBuilder) Where(conds ...Condition) *DeleteBuilder {
+	b.where = whereClause{
+		cond: And(conds...),
+	}
+	return b
+}
+
+// All allows the query to delete all rows without a WHERE clause.
+func (b *DeleteBuilder) All() *DeleteBuilder {
+	b.all = true
+	return b
+}
+
+// Returning sets columns returned by the query. Use Query or QueryRow to read them.
+func (b *DeleteBuilder) Returning(cols ...string) *DeleteBuilder {
+	b.returning = cols
+	return b
+}
+
+// AffectedRows sets the variable to store the number of affected rows when executing the query.
+func (b *DeleteBuilder) AffectedRows(affectedRows *int64) *DeleteBuilder {
+	b.affectedRows = affectedRows
+	return b
+}
+
+// SQL compiles all provided data to return a DELETE query and arguments.
+// It returns an error if there is no WHERE clause unless All is called.
+func (b DeleteBuilder) SQL() (string, []interface{}, error) {
+	if b.where == nil && !b.all {
+		return "", nil, errors.New("sqlb: WHERE clause is required, use All to delete all rows")
+	}
+
+	sb := &strings.Builder{}
+	args := argumentList{}
+
+	_, _ = sb.WriteString("DELETE FROM ")
+	if err := b.table.Build(sb, &args); err != nil {
+		return "", nil, err
+	}
+
+	for i, table := range b.using {
+		if i == 0 {
+			_, _ = sb.WriteString(" USING ")
+		} else {
+			_, _ = sb.WriteString(", ")
+		}
+		if err := table.Build(sb, &args); err != nil {
+			return "", nil, err
+		}
+	}
+
+	if b.where != nil {
+		if err := b.where.Build(sb, &args); err != nil {
+			return "", nil, err
+		}
+	}
+
+	_ = b.returning.Build(sb, &args)
+	return sb.String(), args, nil
+}
+
+// Exec executes the DELETE query.
+func (b DeleteBuilder) Exec(ctx context.Context) error {
+	sql, args, err := b.SQL()
+	if err != nil {
+		return err
+	}
+
+	return b.db.Exec(ctx, sql, args, b.affectedRows)
+}
+
+// Query executes the DELETE query and parses returned rows to the given records.
+func (b DeleteBuilder) Query(ctx context.Context, records EntityList) error {
+	sql, args, err := b.SQL()
+	if err != nil {
+		return err
+	}
+
+	return b.returning.query(ctx, b.db, sql, args, records)
+}
+
+// QueryRow executes the DELETE query and parses the first returned row to the given record.
+func (b DeleteBuilder) QueryRow(ctx context.Context, record Entity) error {
+	sql, args, err := b.SQL()
+	if err != nil {
+		return err
+	}
+
+	return b.returning.queryRow(ctx, b.db, sql, args, record)
+}
@@ -0,0 +1,157 @@
+package sqlb_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bongnv/pggo/pkg/sqlb"
+)
+
+func Test_Delete_SQL(t *testing.T) {
+	t.Run("via table name", func(t *testing.T) {
+		sql, args, err := sqlb.DeleteTable("person").Where(sqlb.Equal("id", 1)).SQL()
+		require.NoError(t, err)
+		require.Equal(t, "DELETE FROM person WHERE (id = $1)", sql)
+		require.Equal(t, []interface{}{1}, args)
+	})
+
+	t.Run("via table", func(t *testing.T) {
+		sql, args, err := sqlb.Delete(sqlb.BaseTable("person")).Where(sqlb.Equal("id", 1), sqlb.Equal("name", "Joe")).SQL()
+		require.NoError(t, err)
+		require.Equal(t, "DELETE FROM person WHERE ((id = $1) AND (name = $2))", sql)
+		require.Equal(t, []interface{}{1, "Joe"}, args)
+	})
+
+	t.Run("with using", func(t *testing.T) {
+		sql, args, err := sqlb.DeleteTable("person").
+			UsingTable("org", "plan").
+			Where(sqlb.EqualColumn("person.org_id", "org.id"), sqlb.EqualColumn("org.plan_id", "plan.id"), sqlb.Equal("plan.name", "free")).
+			SQL()
+		require.NoError(t, err)
+		require.Equal(t, "DELETE FROM person USING org, plan WHERE ((person.org_id = org.id) AND (org.plan_id = plan.id) AND (plan.name = $1))", sql)
+		require.Equal(t, []interface{}{"free"}, args)
+	})
+
+	t.Run("without where", func(t *testing.T) {
+		_, _, err := sqlb.DeleteTable("person").SQL()
+		require.EqualError(t, err, "sqlb: WHERE clause is required, use All to delete all rows")
+	})
+
+	t.Run("all rows", func(t *testing.T) {
+		sql, args, err := sqlb.DeleteTable("person").All().SQL()
+		require.NoError(t, err)
+		require.Equal(t, "DELETE FROM person", sql)
+		require.Empty(t, args)
+	})
+
+	t.Run("with returning", func(t *testing.T) {
+		sql, args, err := sqlb.DeleteTable("person").Where(sqlb.Equal("id", 1)).Returning("id", "name").SQL()
+		require.NoError(t, err)
+		require.Equal(t, "DELETE FROM person WHERE (id = $1) RETURNING id, name", sql)
+		require.Equal(t, []interface{}{1}, args)
+	})
+
+	t.Run("with error where", func(t *testing.T) {
+		_, _, err := sqlb.DeleteTable("person").Where(sqlb.In("id")).SQL()
+		require.EqualError(t, err, "values list must not be empty")
+	})
+
+	t.Run("table with error", func(t *testing.T) {
+		table := tableWithErr{
+			err: errors.New("random error"),
+		}
+		_, _, err := sqlb.Delete(table).All().SQL()
+		require.EqualError(t, err, "random error")
+	})
+
+	t.Run("using table with error", func(t *testing.T) {
+		table := tableWithErr{
+			err: errors.New("random error"),
+		}
+		_, _, err := sqlb.DeleteTable("person").Using(table).All().SQL()
+		require.EqualError(t, err, "random error")
+	})
+}
+
+func Test_Delete_Exec(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("without db", func(t *testing.T) {
+		err := sqlb.DeleteTable("person").All().Exec(ctx)
+		require.EqualError(t, err, "sqlb: no DB was provided to execute the query")
+	})
+
+	t.Run("error when creating SQL", func(t *testing.T) {
+		db := &mockExecer{}
+		err := sqlb.MakeDeleteBuilder(db, "person").Exec(ctx)
+		require.EqualError(t, err, "sqlb: WHERE clause is required, use All to delete all rows")
+		require.False(t, db.called)
+	})
+
+	t.Run("run with no error", func(t *testing.T) {
+		db := &mockExecer{
+			affectedRows: 2,
+		}
+		var affectedRows int64
+		err := sqlb.MakeDeleteBuilder(db, "person").
+			Where(sqlb.In("id", 1, 2)).
+			AffectedRows(&affectedRows).
+			Exec(ctx)
+		require.NoError(t, err)
+		require.True(t, db.called)
+		require.Equal(t, "DELETE FROM person WHERE (id IN ($1,$2))", db.sql)
+		require.Equal(t, []interface{}{1, 2}, db.args)
+		require.EqualValues(t, 2, affectedRows)
+	})
+}
+
+func Test_Delete_Query(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("without returning", func(t *testing.T) {
+		db := &mockQueryExecer{}
+		err := sqlb.MakeDeleteBuilder(db, "person").All().QueryRow(ctx, &mockRecord{})
+		require.EqualError(t, err, "sqlb: Returning must be called to query returned rows")
+	})
+
+	t.Run("error when creating SQL", func(t *testing.T) {
+		db := &mockQueryExecer{}
+		err := sqlb.MakeDeleteBuilder(db, "person").Returning("id").Query(ctx, &mockRecords{})
+		require.EqualError(t, err, "sqlb: WHERE clause is required, use All to delete all rows")
+	})
+
+	t.Run("run with no error", func(t *testing.T) {
+		db := &mockQueryExecer{
+			mockDB: mockDB{
+				data: `[{"id":1},{"id":2}]`,
+			},
+		}
+		records := mockRecords{}
+		err := sqlb.MakeDeleteBuilder(db, "person").
+			Where(sqlb.In("id", 1, 2)).
+			Returning("id").
+			Query(ctx, &records)
+		require.NoError(t, err)
+		require.Equal(t, "DELETE FROM person WHERE (id IN ($1,$2)) RETURNING id", db.mockDB.sql)
+		require.Len(t, records, 2)
+	})
+
+	t.Run("query row with no error", func(t *testing.T) {
+		db := &mockQueryExecer{
+			mockDB: mockDB{
+				data: `{"id":1,"name":"Joe"}`,
+			},
+		}
+		record := mockRecord{}
+		err := sqlb.MakeDeleteBuilder(db, "person").
+			Where(sqlb.Equal("id", 1)).
+			Returning("id", "name").
+			QueryRow(ctx, &record)
+		require.NoError(t, err)
+		require.Equal(t, "DELETE FROM person WHERE (id = $1) RETURNING id, name", db.mockDB.sql)
+		require.Equal(t, "Joe", record.Name)
+	})
+}
@@ -17,6 +17,22 @@ func MakeInsertBuilder(db Execer, table string) *InsertBuilder {
 func MakeSelectBuilder(db Queryer, cols ...string) *SelectBuilder {
 	return &SelectBuilder{
 		db:   db,
-		cols: cols,
+		cols: columnList(cols),
+	}
+}
+
+// MakeUpdateBuilder is exported for testing.
+func MakeUpdateBuilder(db Execer, table string) *UpdateBuilder {
+	return &UpdateBuilder{
+		db:    db,
+		table: BaseTable(table),
+	}
+}
+
+// MakeDeleteBuilder is exported for testing.
+func MakeDeleteBuilder(db Execer, table string) *DeleteBuilder {
+	return &DeleteBuilder{
+		db:    db,
+		table: BaseTable(table),
 	}
 }
@@ -20,7 +20,7 @@ type Factory struct {
 // Select starts a new SELECT query.
 func (f Factory) Select(cols ...string) *SelectBuilder {
 	return &SelectBuilder{
-		cols: cols,
+		cols: columnList(cols),
 		db:   f.DB,
 	}
 }
@@ -38,6 +38,32 @@ func (f Factory) InsertTable(tableName string) *InsertBuilder {
 	return f.Insert(BaseTable(tableName))
 }
 
+// Update starts a new UPDATE query.
+func (f Factory) Update(table Table) *UpdateBuilder {
+	return &UpdateBuilder{
+		table: table,
+		db:    f.DB,
+	}
+}
+
+// UpdateTable starts a new UPDATE query with a table name.
+func (f Factory) UpdateTable(tableName string) *UpdateBuilder {
+	return f.Update(BaseTable(tableName))
+}
+
+// Delete starts a new DELETE query.
+func (f Factory) Delete(table Table) *DeleteBuilder {
+	return &DeleteBuilder{
+		table: table,
+		db:    f.DB,
+	}
+}
+
+// DeleteTable starts a new DELETE query with a table name.
+func (f Factory) DeleteTable(tableName string) *DeleteBuilder {
+	return f.Delete(BaseTable(tableName))
+}
+
 // DefaultFactory is the default factory.
 var DefaultFactory = Factory{
 	DB: noopDB{},
@@ -12,11 +12,44 @@ type Execer interface {
 	Exec(ctx context.Context, sql string, arg []interface{}, affectedRows *int64) error
 }
 
+// Default is a value inserting the default of a column, e.g. Values(sqlb.Default, "Joe").
+// It's only valid in values of INSERT queries.
+var Default = defaultValue{}
+
+type defaultValue struct{}
+
+// rowPlaceholder contains values of a row in INSERT queries, Default is written as DEFAULT.
+type rowPlaceholder []interface{}
+
+func (r rowPlaceholder) Build(sw io.StringWriter, args Placeholders) error {
+	if len(r) == 0 {
+		return errors.New("values list must not be empty")
+	}
+
+	_, _ = sw.WriteString("(")
+	for i, v := range r {
+		if i > 0 {
+			_, _ = sw.WriteString(",")
+		}
+
+		if v == Default {
+			_, _ = sw.WriteString("DEFAULT")
+		} else {
+			_, _ = sw.WriteString(args.Append(v))
+		}
+	}
+	_, _ = sw.WriteString(")")
+
+	return nil
+}
+
 // InsertBuilder is a builder to build an INSERT query.
 type InsertBuilder struct {
 	cols         []string
 	table        Table
 	values       []Builder
+	onConflict   *onConflictClause
+	returning    returningClause
 	db           Execer
 	affectedRows *int64
 }
@@ -30,9 +63,7 @@ func (b *InsertBuilder) Columns(cols ...string) *InsertBuilder {
 // Values adds a single row's values to the query.
 // Multiple calls will create multiple rows to the query.
 func (b *InsertBuilder) Values(values ...interface{}) *InsertBuilder {
-	b.values = append(b.values, groupPlaceholder{
-		values: values,
-	})
+	b.values = append(b.values, rowPlaceholder(values))
 	return b
 }
 
@@ -45,21 +76,77 @@ func (b *InsertBuilder) AffectedRows(affectedRows *int64) *InsertBuilder {
 // Entities adds a single row or multiple rows to the query via Entity objects.
 func (b *InsertBuilder) Entities(entities ...Entity) *InsertBuilder {
 	for _, e := range entities {
+		e := e
 		var valBuilder builderFn = func(sw io.StringWriter, args Placeholders) error {
 			values, err := e.GetValues(b.cols)
 			if err != nil {
 				return err
 			}
 
-			return groupPlaceholder{
-				values: values,
-			}.Build(sw, args)
+			return rowPlaceholder(values).Build(sw, args)
 		}
 		b.values = append(b.values, valBuilder)
 	}
 	return b
 }
 
+// OnConflict sets columns of the unique index to detect conflicts for the ON CONFLICT clause.
+// It must be followed by DoNothing or DoUpdateSet. Without columns, any conflict is detected,
+// which is only valid with DoNothing.
+func (b *InsertBuilder) OnConflict(cols ...string) *InsertBuilder {
+	b.conflict().target = ""
+	if len(cols) > 0 {
+		b.conflict().target = "(" + strings.Join(cols, ",") + ")"
+	}
+	return b
+}
+
+// OnConflictConstraint sets the name of the constraint to detect conflicts for the ON CONFLICT clause.
+// It must be followed by DoNothing or DoUpdateSet.
+func (b *InsertBuilder) OnConflictConstraint(name string) *InsertBuilder {
+	b.conflict().target = "ON CONSTRAINT " + name
+	return b
+}
+
+// DoNothing skips rows that conflict with existing rows.
+func (b *InsertBuilder) DoNothing() *InsertBuilder {
+	b.conflict().doNothing = true
+	return b
+}
+
+// DoUpdateSet updates a column of the existing row when a row conflicts. Multiple calls update multiple columns.
+// Use Excluded to refer to the value proposed for insertion.
+func (b *InsertBuilder) DoUpdateSet(col string, value interface{}) *InsertBuilder {
+	c := b.conflict()
+	c.sets = append(c.sets, setClause{
+		cols:   []string{col},
+		values: []interface{}{value},
+	})
+	return b
+}
+
+// DoUpdateWhere sets the condition of updating existing rows, rows not satisfying it aren't updated.
+func (b *InsertBuilder) DoUpdateWhere(conds ...Condition) *InsertBuilder {
+	b.conflict().where = whereClause{
+		cond: And(conds...),
+	}
+	return b
+}
+
+// Returning sets columns returned by the query, e.g. server-generated IDs. Use Query or QueryRow to read them.
+func (b *InsertBuilder) Returning(cols ...string) *InsertBuilder {
+	b.returning = cols
+	return b
+}
+
+func (b *InsertBuilder) conflict() *onConflictClause {
+	if b.onConflict == nil {
+		b.onConflict = &onConflictClause{}
+	}
+
+	return b.onConflict
+}
+
 // SQL compiles all provided data to return an INSERT query and arguments.
 func (b InsertBuilder) SQL() (string, []interface{}, error) {
 	if len(b.values) == 0 {
@@ -97,6 +184,13 @@ func (b InsertBuilder) SQL() (string, []interface{}, error) {
 		}
 	}
 
+	if b.onConflict != nil {
+		if err := b.onConflict.Build(sb, &args); err != nil {
+			return "", nil, err
+		}
+	}
+
+	_ = b.returning.Build(sb, &args)
 	return sb.String(), args, nil
 }
 
@@ -110,8 +204,75 @@ func (b InsertBuilder) Exec(ctx context.Context) error {
 	return b.db.Exec(ctx, sql, args, b.affectedRows)
 }
 
+// Query executes the INSERT query and parses returned rows to the given records.
+func (b InsertBuilder) Query(ctx context.Context, records EntityList) error {
+	sql, args, err := b.SQL()
+	if err != nil {
+		return err
+	}
+
+	return b.returning.query(ctx, b.db, sql, args, records)
+}
+
+// QueryRow executes the INSERT query and parses the first returned row to the given record.
+func (b InsertBuilder) QueryRow(ctx context.Context, record Entity) error {
+	sql, args, err := b.SQL()
+	if err != nil {
+		return err
+	}
+
+	return b.returning.queryRow(ctx, b.db, sql, args, record)
+}
+
 type builderFn func(sw io.StringWriter, args Placeholders) error
 
 func (f builderFn) Build(sw io.StringWriter, args Placeholders) error {
 	return f(sw, args)
 }
+
+type onConflictClause struct {
+	target    string
+	doNothing bool
+	sets      []Builder
+	where     Builder
+}
+
+func (c onConflictClause) Build(sw io.StringWriter, aa Placeholders) error {
+	switch {
+	case c.doNothing && len(c.sets) > 0:
+		return errors.New("sqlb: ON CONFLICT can't have both DO NOTHING and DO UPDATE")
+	case !c.doNothing && len(c.sets) == 0:
+		return errors.New("sqlb: ON CONFLICT requires DoNothing or DoUpdateSet")
+	case len(c.sets) > 0 && c.target == "":
+		return errors.New("sqlb: ON CONFLICT DO UPDATE requires OnConflict or OnConflictConstraint")
+	case c.where != nil && len(c.sets) == 0:
+		return errors.New("sqlb: DoUpdateWhere requires DoUpdateSet")
+	}
+
+	_, _ = sw.WriteString(" ON CONFLICT")
+	if c.target != "" {
+		_, _ = sw.WriteString(" ")
+		_, _ = sw.WriteString(c.target)
+	}
+
+	if c.doNothing {
+		_, _ = sw.WriteString(" DO NOTHING")
+		return nil
+	}
+
+	_, _ = sw.WriteString(" DO UPDATE SET ")
+	for i, set := range c.sets {
+		if i > 0 {
+			_, _ = sw.WriteString(", ")
+		}
+		if err := set.Build(sw, aa); err != nil {
+			return err
+		}
+	}
+
+	if c.where != nil {
+		return c.where.Build(sw, aa)
+	}
+
+	return nil
+}
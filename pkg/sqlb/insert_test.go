@@ -42,6 +42,13 @@ func Test_Insert_SQL(t *testing.T) {
 		require.Equal(t, []interface{}{1, "Joe", 2, "Two"}, args)
 	})
 
+	t.Run("insert with default values", func(t *testing.T) {
+		sql, args, err := sqlb.InsertTable("person").Columns("id", "name").Values(sqlb.Default, "Joe").Values(2, "Two").SQL()
+		require.NoError(t, err)
+		require.Equal(t, "INSERT INTO person (id,name) VALUES (DEFAULT,$1),($2,$3)", sql)
+		require.Equal(t, []interface{}{"Joe", 2, "Two"}, args)
+	})
+
 	t.Run("zero row of values", func(t *testing.T) {
 		sql, args, err := sqlb.InsertTable("person").Columns("id", "name").SQL()
 		require.EqualError(t, err, "sqlb: there must be at least one row")
@@ -61,6 +68,16 @@ func Test_Insert_SQL(t *testing.T) {
 		require.Equal(t, []interface{}{1, "Joe"}, args)
 	})
 
+	t.Run("with multiple entities", func(t *testing.T) {
+		sql, args, err := sqlb.InsertTable("person").
+			Columns("id", "name").
+			Entities(&mockRecord{ID: 1, Name: "Joe"}, &mockRecord{ID: 2, Name: "Jane"}).
+			SQL()
+		require.NoError(t, err)
+		require.Equal(t, "INSERT INTO person (id,name) VALUES ($1,$2),($3,$4)", sql)
+		require.Equal(t, []interface{}{1, "Joe", 2, "Jane"}, args)
+	})
+
 	t.Run("with non exist columne & entity", func(t *testing.T) {
 		_, _, err := sqlb.InsertTable("person").
 			Columns("id", "non_exist").
@@ -81,6 +98,102 @@ func Test_Insert_SQL(t *testing.T) {
 	})
 }
 
+func Test_Insert_Returning(t *testing.T) {
+	t.Run("with values", func(t *testing.T) {
+		sql, args, err := sqlb.InsertTable("person").Columns("name").Values("Joe").Returning("id", "created_at").SQL()
+		require.NoError(t, err)
+		require.Equal(t, "INSERT INTO person (name) VALUES ($1) RETURNING id, created_at", sql)
+		require.Equal(t, []interface{}{"Joe"}, args)
+	})
+
+	t.Run("after on conflict", func(t *testing.T) {
+		sql, _, err := sqlb.InsertTable("person").
+			Columns("id", "name").
+			Values(1, "Joe").
+			OnConflict("id").
+			DoUpdateSet("name", sqlb.Excluded("name")).
+			Returning("id").
+			SQL()
+		require.NoError(t, err)
+		require.Equal(t, "INSERT INTO person (id,name) VALUES ($1,$2) ON CONFLICT (id) DO UPDATE SET name = EXCLUDED.name RETURNING id", sql)
+	})
+}
+
+func Test_Insert_OnConflict(t *testing.T) {
+	t.Run("do nothing", func(t *testing.T) {
+		sql, args, err := sqlb.InsertTable("event").Columns("id", "name").Values(1, "Joe").OnConflict("id").DoNothing().SQL()
+		require.NoError(t, err)
+		require.Equal(t, "INSERT INTO event (id,name) VALUES ($1,$2) ON CONFLICT (id) DO NOTHING", sql)
+		require.Equal(t, []interface{}{1, "Joe"}, args)
+	})
+
+	t.Run("do nothing without target", func(t *testing.T) {
+		sql, _, err := sqlb.InsertTable("event").Values(1, "Joe").DoNothing().SQL()
+		require.NoError(t, err)
+		require.Equal(t, "INSERT INTO event VALUES ($1,$2) ON CONFLICT DO NOTHING", sql)
+	})
+
+	t.Run("on conflict without columns", func(t *testing.T) {
+		sql, _, err := sqlb.InsertTable("event").Values(1, "Joe").OnConflict().DoNothing().SQL()
+		require.NoError(t, err)
+		require.Equal(t, "INSERT INTO event VALUES ($1,$2) ON CONFLICT DO NOTHING", sql)
+
+		_, _, err = sqlb.InsertTable("event").Values(1, "Joe").OnConflict().DoUpdateSet("name", "Joe").SQL()
+		require.EqualError(t, err, "sqlb: ON CONFLICT DO UPDATE requires OnConflict or OnConflictConstraint")
+	})
+
+	t.Run("do update with entities", func(t *testing.T) {
+		sql, args, err := sqlb.InsertTable("event").
+			Columns("id", "name").
+			Entities(&mockRecord{ID: 1, Name: "Joe"}, &mockRecord{ID: 2, Name: "Jane"}).
+			OnConflict("id", "name").
+			DoUpdateSet("name", sqlb.Excluded("name")).
+			DoUpdateSet("version", 2).
+			SQL()
+		require.NoError(t, err)
+		require.Equal(t, "INSERT INTO event (id,name) VALUES ($1,$2),($3,$4) ON CONFLICT (id,name) DO UPDATE SET name = EXCLUDED.name, version = $5", sql)
+		require.Equal(t, []interface{}{1, "Joe", 2, "Jane", 2}, args)
+	})
+
+	t.Run("do update on constraint with where", func(t *testing.T) {
+		sql, args, err := sqlb.InsertTable("event").
+			Columns("id", "name").
+			Values(1, "Joe").
+			OnConflictConstraint("event_pkey").
+			DoUpdateSet("name", sqlb.Excluded("name")).
+			DoUpdateWhere(sqlb.Equal("event.name", "Unknown")).
+			SQL()
+		require.NoError(t, err)
+		require.Equal(t, "INSERT INTO event (id,name) VALUES ($1,$2) ON CONFLICT ON CONSTRAINT event_pkey DO UPDATE SET name = EXCLUDED.name WHERE (event.name = $3)", sql)
+		require.Equal(t, []interface{}{1, "Joe", "Unknown"}, args)
+	})
+
+	t.Run("without action", func(t *testing.T) {
+		_, _, err := sqlb.InsertTable("event").Values(1, "Joe").OnConflict("id").SQL()
+		require.EqualError(t, err, "sqlb: ON CONFLICT requires DoNothing or DoUpdateSet")
+	})
+
+	t.Run("with both actions", func(t *testing.T) {
+		_, _, err := sqlb.InsertTable("event").Values(1, "Joe").OnConflict("id").DoNothing().DoUpdateSet("name", "Joe").SQL()
+		require.EqualError(t, err, "sqlb: ON CONFLICT can't have both DO NOTHING and DO UPDATE")
+	})
+
+	t.Run("do update without target", func(t *testing.T) {
+		_, _, err := sqlb.InsertTable("event").Values(1, "Joe").DoUpdateSet("name", "Joe").SQL()
+		require.EqualError(t, err, "sqlb: ON CONFLICT DO UPDATE requires OnConflict or OnConflictConstraint")
+	})
+
+	t.Run("do update where without set", func(t *testing.T) {
+		_, _, err := sqlb.InsertTable("event").Values(1, "Joe").OnConflict("id").DoNothing().DoUpdateWhere(sqlb.Equal("id", 1)).SQL()
+		require.EqualError(t, err, "sqlb: DoUpdateWhere requires DoUpdateSet")
+	})
+
+	t.Run("with error where", func(t *testing.T) {
+		_, _, err := sqlb.InsertTable("event").Values(1, "Joe").OnConflict("id").DoUpdateSet("name", "Joe").DoUpdateWhere(sqlb.In("id")).SQL()
+		require.EqualError(t, err, "values list must not be empty")
+	})
+}
+
 type mockExecer struct {
 	called       bool
 	err          error
@@ -99,6 +212,12 @@ func (m *mockExecer) Exec(_ context.Context, sql string, args []interface{}, aff
 	return m.err
 }
 
+// mockQueryExecer executes queries via mockExecer and reads returned rows via mockDB.
+type mockQueryExecer struct {
+	mockExecer
+	mockDB
+}
+
 func Test_Insert_Exec(t *testing.T) {
 	ctx := context.Background()
 
@@ -138,3 +257,86 @@ func Test_Insert_Exec(t *testing.T) {
 		require.EqualValues(t, 1, affectedRows)
 	})
 }
+
+func Test_Insert_Query(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("without db", func(t *testing.T) {
+		err := sqlb.InsertTable("person").Values(1, "Joe").Returning("id").Query(ctx, &mockRecords{})
+		require.EqualError(t, err, "sqlb: no DB was provided to execute the query")
+	})
+
+	t.Run("without returning", func(t *testing.T) {
+		db := &mockQueryExecer{}
+		err := sqlb.MakeInsertBuilder(db, "person").Values(1, "Joe").Query(ctx, &mockRecords{})
+		require.EqualError(t, err, "sqlb: Returning must be called to query returned rows")
+		require.Empty(t, db.mockDB.sql)
+	})
+
+	t.Run("db doesn't support queries", func(t *testing.T) {
+		db := &mockExecer{}
+		err := sqlb.MakeInsertBuilder(db, "person").Values(1, "Joe").Returning("id").Query(ctx, &mockRecords{})
+		require.EqualError(t, err, "sqlb: the DB doesn't support queries")
+		require.False(t, db.called)
+	})
+
+	t.Run("error when creating SQL", func(t *testing.T) {
+		db := &mockQueryExecer{}
+		err := sqlb.MakeInsertBuilder(db, "person").Returning("id").Query(ctx, &mockRecords{})
+		require.EqualError(t, err, "sqlb: there must be at least one row")
+	})
+
+	t.Run("run with no error", func(t *testing.T) {
+		db := &mockQueryExecer{
+			mockDB: mockDB{
+				data: `[{"id":1,"name":"Joe"},{"id":2,"name":"Jane"}]`,
+			},
+		}
+		records := mockRecords{}
+		err := sqlb.MakeInsertBuilder(db, "person").
+			Columns("name").
+			Values("Joe").
+			Values("Jane").
+			Returning("id", "name").
+			Query(ctx, &records)
+		require.NoError(t, err)
+		require.Equal(t, "INSERT INTO person (name) VALUES ($1),($2) RETURNING id, name", db.mockDB.sql)
+		require.Len(t, records, 2)
+		require.Equal(t, 2, records[1].ID)
+		require.False(t, db.called)
+	})
+}
+
+func Test_Insert_QueryRow(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("without returning", func(t *testing.T) {
+		db := &mockQueryExecer{}
+		err := sqlb.MakeInsertBuilder(db, "person").Values(1, "Joe").QueryRow(ctx, &mockRecord{})
+		require.EqualError(t, err, "sqlb: Returning must be called to query returned rows")
+	})
+
+	t.Run("error is propagated properly", func(t *testing.T) {
+		db := &mockQueryExecer{
+			mockDB: mockDB{
+				err: errors.New("db error"),
+			},
+		}
+		err := sqlb.MakeInsertBuilder(db, "person").Columns("name").Values("Joe").Returning("id").QueryRow(ctx, &mockRecord{})
+		require.EqualError(t, err, "db error")
+	})
+
+	t.Run("run with no error", func(t *testing.T) {
+		db := &mockQueryExecer{
+			mockDB: mockDB{
+				data: `{"id":1}`,
+			},
+		}
+		record := mockRecord{Name: "Joe"}
+		err := sqlb.MakeInsertBuilder(db, "person").Columns("name").Entities(&record).Returning("id").QueryRow(ctx, &record)
+		require.NoError(t, err)
+		require.Equal(t, "INSERT INTO person (name) VALUES ($1) RETURNING id", db.mockDB.sql)
+		require.Equal(t, 1, record.ID)
+		require.Equal(t, "Joe", record.Name)
+	})
+}
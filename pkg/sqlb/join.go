@@ -0,0 +1,81 @@
+package sqlb
+
+import (
+	"errors"
+	"io"
+	"strings"
+)
+
+// JoinConstraint is the join condition of a JOIN clause, either On or Using.
+type JoinConstraint interface {
+	Builder
+	joinConstraintOnly()
+}
+
+// On creates an ON join condition.
+func On(conds ...Condition) JoinConstraint {
+	return onConstraint{
+		cond: And(conds...),
+	}
+}
+
+// Using creates a USING join condition from columns which both tables have.
+func Using(cols ...string) JoinConstraint {
+	return usingConstraint(cols)
+}
+
+type onConstraint struct {
+	cond Condition
+}
+
+func (c onConstraint) Build(sw io.StringWriter, aa Placeholders) error {
+	_, _ = sw.WriteString(" ON ")
+	return c.cond.Build(sw, aa)
+}
+
+func (c onConstraint) joinConstraintOnly() {}
+
+type usingConstraint []string
+
+func (c usingConstraint) Build(sw io.StringWriter, _ Placeholders) error {
+	if len(c) == 0 {
+		return errors.New("sqlb: USING requires at least one column")
+	}
+
+	_, _ = sw.WriteString(" USING (")
+	_, _ = sw.WriteString(strings.Join(c, ", "))
+	_, _ = sw.WriteString(")")
+	return nil
+}
+
+func (c usingConstraint) joinConstraintOnly() {}
+
+type joinClause struct {
+	kind       string
+	lateral    bool
+	table      Table
+	constraint JoinConstraint
+}
+
+func (c joinClause) Build(sw io.StringWriter, aa Placeholders) error {
+	_, _ = sw.WriteString(" ")
+	_, _ = sw.WriteString(c.kind)
+	_, _ = sw.WriteString(" JOIN ")
+	if c.lateral {
+		_, _ = sw.WriteString("LATERAL ")
+	}
+
+	if err := c.table.Build(sw, aa); err != nil {
+		return err
+	}
+
+	if c.kind == "CROSS" {
+		return nil
+	}
+
+	if c.constraint == nil {
+		return errors.New("sqlb: " + c.kind + " JOIN requires On or Using")
+	}
+
+	return c.constraint.Build(sw, aa)
+}
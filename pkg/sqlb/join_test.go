@@ -0,0 +1,98 @@
+package sqlb_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bongnv/pggo/pkg/sqlb"
+)
+
+func Test_SelectBuilder_Join(t *testing.T) {
+	t.Run("join on", func(t *testing.T) {
+		sql, args, err := sqlb.Select("person.name", "org.name").
+			FromTable("person").
+			Join(sqlb.BaseTable("org"), sqlb.On(sqlb.EqualColumn("person.org_id", "org.id"), sqlb.Equal("org.active", true))).
+			Where(sqlb.Equal("person.id", 1)).
+			SQL()
+		require.NoError(t, err)
+		require.Equal(t, "SELECT person.name, org.name FROM person INNER JOIN org ON ((person.org_id = org.id) AND (org.active = $1)) WHERE (person.id = $2)", sql)
+		require.Equal(t, []interface{}{true, 1}, args)
+	})
+
+	t.Run("join using", func(t *testing.T) {
+		sql, _, err := sqlb.Select("name").
+			FromTable("person").
+			LeftJoin(sqlb.BaseTable("org"), sqlb.Using("org_id", "region")).
+			SQL()
+		require.NoError(t, err)
+		require.Equal(t, "SELECT name FROM person LEFT JOIN org USING (org_id, region)", sql)
+	})
+
+	t.Run("same table with aliases", func(t *testing.T) {
+		sql, _, err := sqlb.Select("e.name", "m.name").
+			From(sqlb.As(sqlb.BaseTable("employee"), "e")).
+			RightJoin(sqlb.As(sqlb.BaseTable("employee"), "m"), sqlb.On(sqlb.EqualColumn("e.manager_id", "m.id"))).
+			FullJoin(sqlb.As(sqlb.BaseTable("team"), "t"), sqlb.On(sqlb.EqualColumn("t.id", "e.team_id"))).
+			CrossJoin(sqlb.BaseTable("region")).
+			SQL()
+		require.NoError(t, err)
+		require.Equal(t, "SELECT e.name, m.name FROM employee AS e RIGHT JOIN employee AS m ON (e.manager_id = m.id) FULL JOIN team AS t ON (t.id = e.team_id) CROSS JOIN region", sql)
+	})
+
+	t.Run("subqueries", func(t *testing.T) {
+		orders := sqlb.Select("user_id", "total").
+			FromTable("orders").
+			Where(sqlb.Equal("status", "paid"))
+		latest := sqlb.Select("id").
+			FromTable("login").
+			Where(sqlb.EqualColumn("login.user_id", "u.id"), sqlb.Equal("login.success", true))
+		sql, args, err := sqlb.Select("u.name", "o.total", "l.id").
+			From(sqlb.As(sqlb.BaseTable("users"), "u")).
+			Join(sqlb.Subquery(orders, "o"), sqlb.On(sqlb.EqualColumn("o.user_id", "u.id"))).
+			JoinLateral(sqlb.Subquery(latest, "l"), sqlb.On(sqlb.Equal("u.active", true))).
+			Where(sqlb.Equal("u.id", 1)).
+			SQL()
+		require.NoError(t, err)
+		require.Equal(t, "SELECT u.name, o.total, l.id FROM users AS u "+
+			"INNER JOIN (SELECT user_id, total FROM orders WHERE (status = $1)) AS o ON (o.user_id = u.id) "+
+			"INNER JOIN LATERAL (SELECT id FROM login WHERE ((login.user_id = u.id) AND (login.success = $2))) AS l ON (u.active = $3) "+
+			"WHERE (u.id = $4)", sql)
+		require.Equal(t, []interface{}{"paid", true, true, 1}, args)
+	})
+
+	t.Run("without from", func(t *testing.T) {
+		_, _, err := sqlb.Select("id").Join(sqlb.BaseTable("org"), sqlb.Using("id")).SQL()
+		require.EqualError(t, err, "sqlb: JOIN requires a FROM clause")
+	})
+
+	t.Run("without constraint", func(t *testing.T) {
+		_, _, err := sqlb.Select("id").FromTable("person").LeftJoin(sqlb.BaseTable("org"), nil).SQL()
+		require.EqualError(t, err, "sqlb: LEFT JOIN requires On or Using")
+	})
+
+	t.Run("using without columns", func(t *testing.T) {
+		_, _, err := sqlb.Select("id").FromTable("person").Join(sqlb.BaseTable("org"), sqlb.Using()).SQL()
+		require.EqualError(t, err, "sqlb: USING requires at least one column")
+	})
+
+	t.Run("on with error", func(t *testing.T) {
+		_, _, err := sqlb.Select("id").FromTable("person").Join(sqlb.BaseTable("org"), sqlb.On(sqlb.In("org.id"))).SQL()
+		require.EqualError(t, err, "values list must not be empty")
+	})
+
+	t.Run("table with error", func(t *testing.T) {
+		table := tableWithErr{
+			err: errors.New("random error"),
+		}
+		_, _, err := sqlb.Select("id").FromTable("person").CrossJoin(sqlb.As(table, "t")).SQL()
+		require.EqualError(t, err, "random error")
+	})
+
+	t.Run("subquery with error", func(t *testing.T) {
+		sub := sqlb.Select("id").FromTable("org").Where(sqlb.In("id"))
+		_, _, err := sqlb.Select("id").FromTable("person").Join(sqlb.Subquery(sub, "o"), sqlb.Using("id")).SQL()
+		require.EqualError(t, err, "values list must not be empty")
+	})
+}
@@ -0,0 +1,147 @@
+package sqlb
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// JSONB is a jsonb expression whose fields can be accessed, e.g. a jsonb column.
+// Keys and paths are passed as arguments.
+type JSONB struct {
+	expr Builder
+}
+
+// JSONColumn starts a jsonb expression from a column.
+func JSONColumn(col string) JSONB {
+	return JSONB{expr: columnRef(col)}
+}
+
+// Get accesses a field by key via ->, the result is jsonb.
+func (j JSONB) Get(key string) JSONB {
+	return j.access("->", key, "")
+}
+
+// Index accesses an array element via ->, negative indexes count from the end. The result is jsonb.
+func (j JSONB) Index(i int) JSONB {
+	return j.access("->", i, "::int")
+}
+
+// GetPath accesses a field at the given path of keys or array indexes via #>, the result is jsonb.
+func (j JSONB) GetPath(path ...string) JSONB {
+	return j.access("#>", path, "::text[]")
+}
+
+// GetText accesses a field by key via ->>, the result is text.
+func (j JSONB) GetText(key string) Expression {
+	return j.access("->>", key, "")
+}
+
+func (j JSONB) access(operator string, key interface{}, cast string) JSONB {
+	return JSONB{
+		expr: jsonOperation{
+			left:     j,
+			operator: operator,
+			value:    key,
+			cast:     cast,
+		},
+	}
+}
+
+// Build adds the jsonb expression to the SQL query.
+func (j JSONB) Build(sw io.StringWriter, aa Placeholders) error {
+	return j.expr.Build(sw, aa)
+}
+
+func (j JSONB) expressionOnly() {}
+
+// JSONContains creates a @> condition checking whether a jsonb column contains the value.
+// The value is encoded via encoding/json, use json.RawMessage for encoded JSON.
+func JSONContains(column string, value interface{}) Condition {
+	return binaryCond{
+		operator: "@>",
+		col:      columnRef(column),
+		value:    jsonPlaceholder{value: value},
+	}
+}
+
+// JSONHasKey creates a ? condition checking whether a key exists at the top level of a jsonb column.
+func JSONHasKey(column string, key string) Condition {
+	return jsonCond(column, "?", key, "")
+}
+
+// JSONHasAnyKey creates a ?| condition checking whether any of the keys exists at the top level of a jsonb column.
+func JSONHasAnyKey(column string, keys ...string) Condition {
+	return jsonCond(column, "?|", keys, "::text[]")
+}
+
+// JSONHasAllKeys creates a ?& condition checking whether all of the keys exist at the top level of a jsonb column.
+func JSONHasAllKeys(column string, keys ...string) Condition {
+	return jsonCond(column, "?&", keys, "::text[]")
+}
+
+// JSONPathExists creates a @? condition checking whether a jsonpath returns any item for a jsonb column.
+func JSONPathExists(column string, path string) Condition {
+	return jsonCond(column, "@?", path, "::jsonpath")
+}
+
+// JSONPathMatch creates a @@ condition checking the result of a jsonpath predicate for a jsonb column.
+func JSONPathMatch(column string, path string) Condition {
+	return jsonCond(column, "@@", path, "::jsonpath")
+}
+
+func jsonCond(column, operator string, value interface{}, cast string) Condition {
+	return binaryCond{
+		operator: operator,
+		col:      columnRef(column),
+		value:    castPlaceholder{value: value, cast: cast},
+	}
+}
+
+// jsonOperation applies a jsonb operator, it's in parentheses so operations can be chained.
+type jsonOperation struct {
+	left     Builder
+	operator string
+	value    interface{}
+	cast     string
+}
+
+func (o jsonOperation) Build(sw io.StringWriter, aa Placeholders) error {
+	_, _ = sw.WriteString("(")
+	if err := o.left.Build(sw, aa); err != nil {
+		return err
+	}
+	_, _ = sw.WriteString(" ")
+	_, _ = sw.WriteString(o.operator)
+	_, _ = sw.WriteString(" ")
+	if err := (castPlaceholder{value: o.value, cast: o.cast}).Build(sw, aa); err != nil {
+		return err
+	}
+	_, _ = sw.WriteString(")")
+	return nil
+}
+
+// castPlaceholder is a placeholder with a type cast, as the type of arguments can't be inferred from jsonb operators.
+type castPlaceholder struct {
+	value interface{}
+	cast  string
+}
+
+func (p castPlaceholder) Build(sw io.StringWriter, aa Placeholders) error {
+	_, _ = sw.WriteString(aa.Append(p.value))
+	_, _ = sw.WriteString(p.cast)
+	return nil
+}
+
+// jsonPlaceholder encodes a Go value into JSON before passing it as a jsonb argument.
+type jsonPlaceholder struct {
+	value interface{}
+}
+
+func (p jsonPlaceholder) Build(sw io.StringWriter, aa Placeholders) error {
+	data, err := json.Marshal(p.value)
+	if err != nil {
+		return err
+	}
+
+	return castPlaceholder{value: string(data), cast: "::jsonb"}.Build(sw, aa)
+}
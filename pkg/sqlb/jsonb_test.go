@@ -0,0 +1,94 @@
+package sqlb_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bongnv/pggo/pkg/sqlb"
+)
+
+func Test_JSONB_Accessors(t *testing.T) {
+	t.Run("in select list, conditions and ordering", func(t *testing.T) {
+		attrs := sqlb.JSONColumn("attrs")
+		sql, args, err := sqlb.Select("id").
+			SelectExpr(attrs.Get("size").Index(0), "first_size").
+			SelectExpr(attrs.GetPath("dimensions", "width"), "width").
+			FromTable("product").
+			Where(sqlb.Compare(attrs.Get("brand").GetText("name"), "=", "acme")).
+			OrderBy(sqlb.AscExpr(attrs.GetText("color")).NullsLast()).
+			SQL()
+		require.NoError(t, err)
+		require.Equal(t, "SELECT id, ((attrs -> $1) -> $2::int) AS first_size, (attrs #> $3::text[]) AS width FROM product "+
+			"WHERE (((attrs -> $4) ->> $5) = $6) ORDER BY (attrs ->> $7) ASC NULLS LAST", sql)
+		require.Equal(t, []interface{}{"size", 0, []string{"dimensions", "width"}, "brand", "name", "acme", "color"}, args)
+	})
+}
+
+func Test_JSONB_Conditions(t *testing.T) {
+	cases := map[string]struct {
+		cond          sqlb.Condition
+		expectedQuery string
+		expectedArgs  sqlb.ArgumentList
+		expectedErr   string
+	}{
+		"contains": {
+			cond:          sqlb.JSONContains("attrs", map[string]interface{}{"color": "red", "sizes": []int{1, 2}}),
+			expectedQuery: "(attrs @> $1::jsonb)",
+			expectedArgs:  []interface{}{`{"color":"red","sizes":[1,2]}`},
+		},
+		"contains raw message": {
+			cond:          sqlb.JSONContains("attrs", json.RawMessage(`{"color": "red"}`)),
+			expectedQuery: "(attrs @> $1::jsonb)",
+			expectedArgs:  []interface{}{`{"color":"red"}`},
+		},
+		"contains unsupported value": {
+			cond:        sqlb.JSONContains("attrs", func() {}),
+			expectedErr: "json: unsupported type: func()",
+		},
+		"has key": {
+			cond:          sqlb.JSONHasKey("attrs", "color"),
+			expectedQuery: "(attrs ? $1)",
+			expectedArgs:  []interface{}{"color"},
+		},
+		"has any key": {
+			cond:          sqlb.JSONHasAnyKey("attrs", "color", "size"),
+			expectedQuery: "(attrs ?| $1::text[])",
+			expectedArgs:  []interface{}{[]string{"color", "size"}},
+		},
+		"has all keys": {
+			cond:          sqlb.JSONHasAllKeys("attrs", "color", "size"),
+			expectedQuery: "(attrs ?& $1::text[])",
+			expectedArgs:  []interface{}{[]string{"color", "size"}},
+		},
+		"path exists": {
+			cond:          sqlb.JSONPathExists("attrs", "$.sizes[*] ? (@ > 2)"),
+			expectedQuery: "(attrs @? $1::jsonpath)",
+			expectedArgs:  []interface{}{"$.sizes[*] ? (@ > 2)"},
+		},
+		"path match": {
+			cond:          sqlb.JSONPathMatch("attrs", "$.price > 10"),
+			expectedQuery: "(attrs @@ $1::jsonpath)",
+			expectedArgs:  []interface{}{"$.price > 10"},
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			sb := &strings.Builder{}
+			args := sqlb.ArgumentList{}
+			err := tc.cond.Build(sb, &args)
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedQuery, sb.String())
+			require.Equal(t, tc.expectedArgs, args)
+		})
+	}
+}
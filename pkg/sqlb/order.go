@@ -0,0 +1,103 @@
+package sqlb
+
+import (
+	"errors"
+	"io"
+)
+
+// Ordering is a sort expression of an ORDER BY clause.
+type Ordering struct {
+	expr  Builder
+	desc  bool
+	nulls string
+}
+
+// Asc sorts by a column in ascending order.
+func Asc(col string) Ordering {
+	return Ordering{expr: columnRef(col)}
+}
+
+// Desc sorts by a column in descending order.
+func Desc(col string) Ordering {
+	return Ordering{expr: columnRef(col), desc: true}
+}
+
+// AscExpr sorts by an expression in ascending order.
+func AscExpr(expr Expression) Ordering {
+	return Ordering{expr: expr}
+}
+
+// DescExpr sorts by an expression in descending order.
+func DescExpr(expr Expression) Ordering {
+	return Ordering{expr: expr, desc: true}
+}
+
+// NullsFirst sorts NULL values before non-NULL values.
+func (o Ordering) NullsFirst() Ordering {
+	o.nulls = "FIRST"
+	return o
+}
+
+// NullsLast sorts NULL values after non-NULL values.
+func (o Ordering) NullsLast() Ordering {
+	o.nulls = "LAST"
+	return o
+}
+
+// Build adds the sort expression to the SQL query.
+func (o Ordering) Build(sw io.StringWriter, aa Placeholders) error {
+	if o.expr == nil {
+		return errors.New("sqlb: ordering must have a column or an expression")
+	}
+
+	if err := o.expr.Build(sw, aa); err != nil {
+		return err
+	}
+
+	if o.desc {
+		_, _ = sw.WriteString(" DESC")
+	} else {
+		_, _ = sw.WriteString(" ASC")
+	}
+
+	if o.nulls != "" {
+		_, _ = sw.WriteString(" NULLS ")
+		_, _ = sw.WriteString(o.nulls)
+	}
+
+	return nil
+}
+
+type orderByClause []Ordering
+
+func (c orderByClause) Build(sw io.StringWriter, aa Placeholders) error {
+	for i, o := range c {
+		if i == 0 {
+			_, _ = sw.WriteString(" ORDER BY ")
+		} else {
+			_, _ = sw.WriteString(", ")
+		}
+		if err := o.Build(sw, aa); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// limitClause is a LIMIT or OFFSET clause whose value is passed as an argument.
+type limitClause struct {
+	keyword string
+	value   int
+}
+
+func (c limitClause) Build(sw io.StringWriter, aa Placeholders) error {
+	if c.value < 0 {
+		return errors.New("sqlb: " + c.keyword + " must not be negative")
+	}
+
+	_, _ = sw.WriteString(" ")
+	_, _ = sw.WriteString(c.keyword)
+	_, _ = sw.WriteString(" ")
+	_, _ = sw.WriteString(aa.Append(c.value))
+	return nil
+}
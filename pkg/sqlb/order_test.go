@@ -0,0 +1,69 @@
+package sqlb_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bongnv/pggo/pkg/sqlb"
+)
+
+func Test_SelectBuilder_OrderBy(t *testing.T) {
+	t.Run("columns", func(t *testing.T) {
+		sql, args, err := sqlb.Select("id").
+			FromTable("person").
+			Where(sqlb.Equal("active", true)).
+			OrderBy(sqlb.Desc("created_at").NullsLast(), sqlb.Asc("id")).
+			SQL()
+		require.NoError(t, err)
+		require.Equal(t, "SELECT id FROM person WHERE (active = $1) ORDER BY created_at DESC NULLS LAST, id ASC", sql)
+		require.Equal(t, []interface{}{true}, args)
+	})
+
+	t.Run("expressions", func(t *testing.T) {
+		sql, _, err := sqlb.Select("id").
+			FromTable("person").
+			OrderBy(sqlb.AscExpr(sqlb.Expr("lower(name)")).NullsFirst()).
+			OrderBy(sqlb.DescExpr(sqlb.Expr("length(name)"))).
+			SQL()
+		require.NoError(t, err)
+		require.Equal(t, "SELECT id FROM person ORDER BY lower(name) ASC NULLS FIRST, length(name) DESC", sql)
+	})
+
+	t.Run("empty ordering", func(t *testing.T) {
+		_, _, err := sqlb.Select("id").FromTable("person").OrderBy(sqlb.Ordering{}).SQL()
+		require.EqualError(t, err, "sqlb: ordering must have a column or an expression")
+	})
+}
+
+func Test_SelectBuilder_Limit(t *testing.T) {
+	t.Run("limit and offset", func(t *testing.T) {
+		sql, args, err := sqlb.Select("id").
+			FromTable("person").
+			Where(sqlb.Equal("active", true)).
+			OrderBy(sqlb.Asc("id")).
+			Limit(10).
+			Offset(20).
+			SQL()
+		require.NoError(t, err)
+		require.Equal(t, "SELECT id FROM person WHERE (active = $1) ORDER BY id ASC LIMIT $2 OFFSET $3", sql)
+		require.Equal(t, []interface{}{true, 10, 20}, args)
+	})
+
+	t.Run("offset only", func(t *testing.T) {
+		sql, args, err := sqlb.Select("id").FromTable("person").Offset(5).SQL()
+		require.NoError(t, err)
+		require.Equal(t, "SELECT id FROM person OFFSET $1", sql)
+		require.Equal(t, []interface{}{5}, args)
+	})
+
+	t.Run("negative limit", func(t *testing.T) {
+		_, _, err := sqlb.Select("id").FromTable("person").Limit(-1).SQL()
+		require.EqualError(t, err, "sqlb: LIMIT must not be negative")
+	})
+
+	t.Run("negative offset", func(t *testing.T) {
+		_, _, err := sqlb.Select("id").FromTable("person").Limit(1).Offset(-1).SQL()
+		require.EqualError(t, err, "sqlb: OFFSET must not be negative")
+	})
+}
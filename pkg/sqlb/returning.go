@@ -0,0 +1,54 @@
+package sqlb
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// returningClause contains columns of the RETURNING clause of INSERT, UPDATE and DELETE queries.
+type returningClause []string
+
+func (c returningClause) Build(sw io.StringWriter, _ Placeholders) error {
+	for i, col := range c {
+		if i == 0 {
+			_, _ = sw.WriteString(" RETURNING ")
+		} else {
+			_, _ = sw.WriteString(", ")
+		}
+		_, _ = sw.WriteString(col)
+	}
+	return nil
+}
+
+func (c returningClause) query(ctx context.Context, db Execer, sql string, args []interface{}, records EntityList) error {
+	q, err := c.queryer(db)
+	if err != nil {
+		return err
+	}
+
+	return q.Query(ctx, sql, args, records)
+}
+
+func (c returningClause) queryRow(ctx context.Context, db Execer, sql string, args []interface{}, record Entity) error {
+	q, err := c.queryer(db)
+	if err != nil {
+		return err
+	}
+
+	return q.QueryRow(ctx, sql, args, record)
+}
+
+// queryer returns the DB for reading returned rows, write builders only require Execer to execute queries.
+func (c returningClause) queryer(db Execer) (Queryer, error) {
+	if len(c) == 0 {
+		return nil, errors.New("sqlb: Returning must be called to query returned rows")
+	}
+
+	q, ok := db.(Queryer)
+	if !ok {
+		return nil, errors.New("sqlb: the DB doesn't support queries")
+	}
+
+	return q, nil
+}
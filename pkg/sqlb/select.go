@@ -2,6 +2,7 @@ package sqlb
 
 import (
 	"context"
+	"errors"
 	"io"
 	"strconv"
 	"strings"
@@ -38,10 +39,25 @@ type Queryer interface {
 
 // SelectBuilder is a builder implementation of a select query.
 type SelectBuilder struct {
-	cols  []string
-	db    Queryer
-	from  Builder
-	where Builder
+	cols    []Builder
+	db      Queryer
+	from    Builder
+	joins   []Builder
+	where   Builder
+	groupBy groupByClause
+	having  Builder
+	orderBy orderByClause
+	limit   Builder
+	offset  Builder
+}
+
+// SelectExpr adds an expression, e.g. an aggregate, to the select list with an optional alias.
+func (b *SelectBuilder) SelectExpr(expr Expression, alias string) *SelectBuilder {
+	b.cols = append(b.cols, selectExpr{
+		expr:  expr,
+		alias: alias,
+	})
+	return b
 }
 
 // FromTable sets the FROM clause for the query with the table is provided with a string.
@@ -57,6 +73,52 @@ func (b *SelectBuilder) From(table Table) *SelectBuilder {
 	return b
 }
 
+// FromSubquery sets the FROM clause from a SELECT query with the given alias.
+func (b *SelectBuilder) FromSubquery(query *SelectBuilder, alias string) *SelectBuilder {
+	return b.From(Subquery(query, alias))
+}
+
+// Join adds an INNER JOIN clause to the query.
+func (b *SelectBuilder) Join(table Table, constraint JoinConstraint) *SelectBuilder {
+	return b.join("INNER", false, table, constraint)
+}
+
+// LeftJoin adds a LEFT JOIN clause to the query.
+func (b *SelectBuilder) LeftJoin(table Table, constraint JoinConstraint) *SelectBuilder {
+	return b.join("LEFT", false, table, constraint)
+}
+
+// RightJoin adds a RIGHT JOIN clause to the query.
+func (b *SelectBuilder) RightJoin(table Table, constraint JoinConstraint) *SelectBuilder {
+	return b.join("RIGHT", false, table, constraint)
+}
+
+// FullJoin adds a FULL JOIN clause to the query.
+func (b *SelectBuilder) FullJoin(table Table, constraint JoinConstraint) *SelectBuilder {
+	return b.join("FULL", false, table, constraint)
+}
+
+// CrossJoin adds a CROSS JOIN clause to the query.
+func (b *SelectBuilder) CrossJoin(table Table) *SelectBuilder {
+	return b.join("CROSS", false, table, nil)
+}
+
+// JoinLateral adds an INNER JOIN LATERAL clause to the query, the table is usually a Subquery
+// referring to columns of preceding tables.
+func (b *SelectBuilder) JoinLateral(table Table, constraint JoinConstraint) *SelectBuilder {
+	return b.join("INNER", true, table, constraint)
+}
+
+func (b *SelectBuilder) join(kind string, lateral bool, table Table, constraint JoinConstraint) *SelectBuilder {
+	b.joins = append(b.joins, joinClause{
+		kind:       kind,
+		lateral:    lateral,
+		table:      table,
+		constraint: constraint,
+	})
+	return b
+}
+
 // Where sets the WHERE clause for the query.
 func (b *SelectBuilder) Where(conds ...Condition) *SelectBuilder {
 	b.where = whereClause{
@@ -65,6 +127,44 @@ func (b *SelectBuilder) Where(conds ...Condition) *SelectBuilder {
 	return b
 }
 
+// GroupBy adds columns to the GROUP BY clause of the query.
+func (b *SelectBuilder) GroupBy(cols ...string) *SelectBuilder {
+	b.groupBy = append(b.groupBy, cols...)
+	return b
+}
+
+// Having sets the HAVING clause for the query.
+func (b *SelectBuilder) Having(conds ...Condition) *SelectBuilder {
+	b.having = havingClause{
+		cond: And(conds...),
+	}
+	return b
+}
+
+// OrderBy adds sort expressions to the ORDER BY clause of the query.
+func (b *SelectBuilder) OrderBy(orderings ...Ordering) *SelectBuilder {
+	b.orderBy = append(b.orderBy, orderings...)
+	return b
+}
+
+// Limit sets the maximum number of rows returned by the query.
+func (b *SelectBuilder) Limit(limit int) *SelectBuilder {
+	b.limit = limitClause{
+		keyword: "LIMIT",
+		value:   limit,
+	}
+	return b
+}
+
+// Offset sets the number of rows skipped before returning rows.
+func (b *SelectBuilder) Offset(offset int) *SelectBuilder {
+	b.offset = limitClause{
+		keyword: "OFFSET",
+		value:   offset,
+	}
+	return b
+}
+
 // Build builds the SELECT query.
 func (b SelectBuilder) Build(sb io.StringWriter, aa Placeholders) error {
 	_, _ = sb.WriteString("SELECT ")
@@ -73,7 +173,9 @@ func (b SelectBuilder) Build(sb io.StringWriter, aa Placeholders) error {
 		if i > 0 {
 			_, _ = sb.WriteString(", ")
 		}
-		_, _ = sb.WriteString(col)
+		if err := col.Build(sb, aa); err != nil {
+			return err
+		}
 	}
 
 	if b.from != nil {
@@ -82,12 +184,46 @@ func (b SelectBuilder) Build(sb io.StringWriter, aa Placeholders) error {
 		}
 	}
 
+	if len(b.joins) > 0 && b.from == nil {
+		return errors.New("sqlb: JOIN requires a FROM clause")
+	}
+
+	for _, join := range b.joins {
+		if err := join.Build(sb, aa); err != nil {
+			return err
+		}
+	}
+
 	if b.where != nil {
 		if err := b.where.Build(sb, aa); err != nil {
 			return err
 		}
 	}
 
+	_ = b.groupBy.Build(sb, aa)
+
+	if b.having != nil {
+		if err := b.having.Build(sb, aa); err != nil {
+			return err
+		}
+	}
+
+	if err := b.orderBy.Build(sb, aa); err != nil {
+		return err
+	}
+
+	if b.limit != nil {
+		if err := b.limit.Build(sb, aa); err != nil {
+			return err
+		}
+	}
+
+	if b.offset != nil {
+		if err := b.offset.Build(sb, aa); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -131,6 +267,15 @@ func (l *argumentList) Append(values ...interface{}) string {
 	return "$" + strconv.Itoa(len(*l))
 }
 
+// columnList converts column names into the select list.
+func columnList(cols []string) []Builder {
+	list := make([]Builder, len(cols))
+	for i, col := range cols {
+		list[i] = columnRef(col)
+	}
+	return list
+}
+
 type fromClause struct {
 	table Table
 }
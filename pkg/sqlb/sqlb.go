@@ -1,5 +1,57 @@
 package sqlb
 
+import (
+	"errors"
+	"reflect"
+)
+
+var (
+	// ErrNotFound is returned by generated repositories when an entity couldn't be found.
+	ErrNotFound = errors.New("sqlb: entity not found")
+	// ErrDuplicateKey is returned by generated repositories, including in-memory ones, when a unique constraint is violated.
+	ErrDuplicateKey = errors.New("sqlb: duplicate key value violates unique constraint")
+)
+
+// uniqueViolation is the SQLSTATE of unique constraint violations.
+const uniqueViolation = "23505"
+
+// WrapDuplicateKey wraps err so that errors.Is(err, ErrDuplicateKey) is true if it's a unique constraint violation.
+// Errors of drivers are detected via their SQLState method, e.g. *pgconn.PgError of pgx,
+// and they can still be retrieved via errors.As. Other errors are returned as is.
+func WrapDuplicateKey(err error) error {
+	var sqlErr interface{ SQLState() string }
+	if errors.As(err, &sqlErr) && sqlErr.SQLState() == uniqueViolation {
+		return duplicateKeyError{err: err}
+	}
+
+	return err
+}
+
+type duplicateKeyError struct {
+	err error
+}
+
+func (e duplicateKeyError) Error() string {
+	return ErrDuplicateKey.Error() + ": " + e.err.Error()
+}
+
+func (e duplicateKeyError) Is(target error) bool {
+	return target == ErrDuplicateKey
+}
+
+func (e duplicateKeyError) Unwrap() error {
+	return e.err
+}
+
+// DefaultIfZero returns Default if v is the zero value of its type, so that the column default is inserted instead.
+func DefaultIfZero(v interface{}) interface{} {
+	if v == nil || reflect.ValueOf(v).IsZero() {
+		return Default
+	}
+
+	return v
+}
+
 // Insert starts a new INSERT query.
 func Insert(table Table) *InsertBuilder {
 	return DefaultFactory.Insert(table)
@@ -10,6 +62,26 @@ func InsertTable(tableName string) *InsertBuilder {
 	return DefaultFactory.InsertTable(tableName)
 }
 
+// Update starts a new UPDATE query.
+func Update(table Table) *UpdateBuilder {
+	return DefaultFactory.Update(table)
+}
+
+// UpdateTable starts a new UPDATE query with a table name.
+func UpdateTable(tableName string) *UpdateBuilder {
+	return DefaultFactory.UpdateTable(tableName)
+}
+
+// Delete starts a new DELETE query.
+func Delete(table Table) *DeleteBuilder {
+	return DefaultFactory.Delete(table)
+}
+
+// DeleteTable starts a new DELETE query with a table name.
+func DeleteTable(tableName string) *DeleteBuilder {
+	return DefaultFactory.DeleteTable(tableName)
+}
+
 // Select starts a new SELECT query.
 func Select(cols ...string) *SelectBuilder {
 	return DefaultFactory.Select(cols...)
@@ -0,0 +1,48 @@
+package sqlb_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bongnv/pggo/pkg/sqlb"
+)
+
+type sqlStateError struct {
+	code string
+}
+
+func (e *sqlStateError) Error() string {
+	return "ERROR: state " + e.code
+}
+
+func (e *sqlStateError) SQLState() string {
+	return e.code
+}
+
+func Test_WrapDuplicateKey(t *testing.T) {
+	require.NoError(t, sqlb.WrapDuplicateKey(nil))
+	require.Equal(t, sqlb.ErrNotFound, sqlb.WrapDuplicateKey(sqlb.ErrNotFound))
+
+	fkErr := &sqlStateError{code: "23503"}
+	require.Equal(t, fkErr, sqlb.WrapDuplicateKey(fkErr))
+
+	err := sqlb.WrapDuplicateKey(fmt.Errorf("insert: %w", &sqlStateError{code: "23505"}))
+	require.True(t, errors.Is(err, sqlb.ErrDuplicateKey))
+	require.EqualError(t, err, "sqlb: duplicate key value violates unique constraint: insert: ERROR: state 23505")
+
+	var stateErr *sqlStateError
+	require.True(t, errors.As(err, &stateErr))
+	require.Equal(t, "23505", stateErr.code)
+}
+
+func Test_DefaultIfZero(t *testing.T) {
+	require.Equal(t, sqlb.Default, sqlb.DefaultIfZero(nil))
+	require.Equal(t, sqlb.Default, sqlb.DefaultIfZero(int32(0)))
+	require.Equal(t, sqlb.Default, sqlb.DefaultIfZero(time.Time{}))
+	require.Equal(t, sqlb.Default, sqlb.DefaultIfZero((*string)(nil)))
+	require.Equal(t, "active", sqlb.DefaultIfZero("active"))
+}
@@ -0,0 +1,70 @@
+package sqlb
+
+import "io"
+
+// InSubquery creates an IN condition whose values are returned by a SELECT query.
+func InSubquery(column string, query *SelectBuilder) Condition {
+	return binaryCond{
+		operator: "IN",
+		col:      columnRef(column),
+		value:    subquery{query: query},
+	}
+}
+
+// Exists creates an EXISTS condition which is true if the SELECT query returns any rows.
+func Exists(query *SelectBuilder) Condition {
+	return existsCond{
+		query: query,
+	}
+}
+
+// NotExists creates a NOT EXISTS condition which is true if the SELECT query returns no rows.
+func NotExists(query *SelectBuilder) Condition {
+	return existsCond{
+		not:   true,
+		query: query,
+	}
+}
+
+// ScalarSubquery uses a SELECT query returning a single value as an expression,
+// e.g. in the select list or as a value of a comparison.
+func ScalarSubquery(query *SelectBuilder) Expression {
+	return subquery{query: query}
+}
+
+// subquery is a SELECT query in parentheses, arguments of the query share placeholders with the outer query.
+type subquery struct {
+	query *SelectBuilder
+}
+
+func (s subquery) Build(sw io.StringWriter, aa Placeholders) error {
+	_, _ = sw.WriteString("(")
+	if err := s.query.Build(sw, aa); err != nil {
+		return err
+	}
+	_, _ = sw.WriteString(")")
+	return nil
+}
+
+func (s subquery) tableOnly() {}
+
+func (s subquery) expressionOnly() {}
+
+type existsCond struct {
+	baseCond
+	not   bool
+	query *SelectBuilder
+}
+
+func (c existsCond) Build(sw io.StringWriter, aa Placeholders) error {
+	_, _ = sw.WriteString("(")
+	if c.not {
+		_, _ = sw.WriteString("NOT ")
+	}
+	_, _ = sw.WriteString("EXISTS ")
+	if err := (subquery{query: c.query}).Build(sw, aa); err != nil {
+		return err
+	}
+	_, _ = sw.WriteString(")")
+	return nil
+}
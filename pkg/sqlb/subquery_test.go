@@ -0,0 +1,78 @@
+package sqlb_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bongnv/pggo/pkg/sqlb"
+)
+
+func Test_Subqueries(t *testing.T) {
+	t.Run("in subquery", func(t *testing.T) {
+		paid := sqlb.Select("user_id").FromTable("orders").Where(sqlb.Equal("status", "paid"))
+		sql, args, err := sqlb.Select("id").
+			FromTable("users").
+			Where(sqlb.Equal("active", true), sqlb.InSubquery("id", paid), sqlb.Equal("country", "VN")).
+			SQL()
+		require.NoError(t, err)
+		require.Equal(t, "SELECT id FROM users WHERE ((active = $1) AND (id IN (SELECT user_id FROM orders WHERE (status = $2))) AND (country = $3))", sql)
+		require.Equal(t, []interface{}{true, "paid", "VN"}, args)
+	})
+
+	t.Run("exists and not exists", func(t *testing.T) {
+		orders := sqlb.Select("1").FromTable("orders").Where(sqlb.EqualColumn("orders.user_id", "users.id"), sqlb.Equal("orders.status", "paid"))
+		bans := sqlb.Select("1").FromTable("bans").Where(sqlb.EqualColumn("bans.user_id", "users.id"))
+		sql, args, err := sqlb.Select("id").
+			FromTable("users").
+			Where(sqlb.Exists(orders), sqlb.NotExists(bans)).
+			SQL()
+		require.NoError(t, err)
+		require.Equal(t, "SELECT id FROM users WHERE ((EXISTS (SELECT 1 FROM orders WHERE ((orders.user_id = users.id) AND (orders.status = $1)))) "+
+			"AND (NOT EXISTS (SELECT 1 FROM bans WHERE (bans.user_id = users.id))))", sql)
+		require.Equal(t, []interface{}{"paid"}, args)
+	})
+
+	t.Run("scalar subqueries", func(t *testing.T) {
+		count := sqlb.Select().SelectExpr(sqlb.Count("*"), "").FromTable("orders").Where(sqlb.EqualColumn("orders.user_id", "users.id"), sqlb.Equal("orders.status", "paid"))
+		latest := sqlb.Select().SelectExpr(sqlb.Max("created_at"), "").FromTable("orders")
+		sql, args, err := sqlb.Select("id").
+			SelectExpr(sqlb.ScalarSubquery(count), "paid_orders").
+			FromTable("users").
+			Where(sqlb.Equal("last_order_at", sqlb.ScalarSubquery(latest)), sqlb.Compare(sqlb.ScalarSubquery(count), ">", 3)).
+			SQL()
+		require.NoError(t, err)
+		require.Equal(t, "SELECT id, (SELECT COUNT(*) FROM orders WHERE ((orders.user_id = users.id) AND (orders.status = $1))) AS paid_orders FROM users "+
+			"WHERE ((last_order_at = (SELECT MAX(created_at) FROM orders)) AND ((SELECT COUNT(*) FROM orders WHERE ((orders.user_id = users.id) AND (orders.status = $2))) > $3))", sql)
+		require.Equal(t, []interface{}{"paid", "paid", 3}, args)
+	})
+
+	t.Run("from subquery", func(t *testing.T) {
+		totals := sqlb.Select("user_id").
+			SelectExpr(sqlb.Sum("amount"), "total").
+			FromTable("orders").
+			Where(sqlb.Equal("status", "paid")).
+			GroupBy("user_id")
+		sql, args, err := sqlb.Select("t.user_id", "t.total").
+			FromSubquery(totals, "t").
+			Where(sqlb.Compare(sqlb.Expr("t.total"), ">=", 100)).
+			OrderBy(sqlb.Desc("t.total")).
+			Limit(5).
+			SQL()
+		require.NoError(t, err)
+		require.Equal(t, "SELECT t.user_id, t.total FROM (SELECT user_id, SUM(amount) AS total FROM orders WHERE (status = $1) GROUP BY user_id) AS t "+
+			"WHERE (t.total >= $2) ORDER BY t.total DESC LIMIT $3", sql)
+		require.Equal(t, []interface{}{"paid", 100, 5}, args)
+	})
+
+	t.Run("subquery with error", func(t *testing.T) {
+		invalid := sqlb.Select("user_id").FromTable("orders").Where(sqlb.In("status"))
+		for _, cond := range []sqlb.Condition{sqlb.InSubquery("id", invalid), sqlb.Exists(invalid), sqlb.NotExists(invalid), sqlb.Equal("id", sqlb.ScalarSubquery(invalid))} {
+			_, _, err := sqlb.Select("id").FromTable("users").Where(cond).SQL()
+			require.EqualError(t, err, "values list must not be empty")
+		}
+
+		_, _, err := sqlb.Select("id").FromSubquery(invalid, "t").SQL()
+		require.EqualError(t, err, "values list must not be empty")
+	})
+}
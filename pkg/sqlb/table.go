@@ -0,0 +1,36 @@
+package sqlb
+
+import "io"
+
+// As gives the table an alias, so the same table can appear multiple times in a query.
+func As(table Table, alias string) Table {
+	return aliasTable{
+		table: table,
+		alias: alias,
+	}
+}
+
+// Subquery uses a SELECT query as a table with the given alias.
+func Subquery(query *SelectBuilder, alias string) Table {
+	return aliasTable{
+		table: subquery{query: query},
+		alias: alias,
+	}
+}
+
+type aliasTable struct {
+	table Table
+	alias string
+}
+
+func (t aliasTable) Build(sw io.StringWriter, aa Placeholders) error {
+	if err := t.table.Build(sw, aa); err != nil {
+		return err
+	}
+
+	_, _ = sw.WriteString(" AS ")
+	_, _ = sw.WriteString(t.alias)
+	return nil
+}
+
+func (t aliasTable) tableOnly() {}
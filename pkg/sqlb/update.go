@@ -0,0 +1,204 @@
+package sqlb
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+)
+
+// UpdateBuilder is a builder to build an UPDATE query.
+type UpdateBuilder struct {
+	table        Table
+	sets         []Builder
+	from         []Table
+	where        Builder
+	all          bool
+	returning    returningClause
+	db           Execer
+	affectedRows *int64
+}
+
+// Set adds a column and its new value to the query.
+func (b *UpdateBuilder) Set(col string, value interface{}) *UpdateBuilder {
+	b.sets = append(b.sets, setClause{
+		cols:   []string{col},
+		values: []interface{}{value},
+	})
+	return b
+}
+
+// SetMap adds columns and their new values to the query. Columns are sorted by names.
+func (b *UpdateBuilder) SetMap(values map[string]interface{}) *UpdateBuilder {
+	cols := make([]string, 0, len(values))
+	for col := range values {
+		cols = append(cols, col)
+	}
+	sort.Strings(cols)
+
+	for _, col := range cols {
+		b.Set(col, values[col])
+	}
+	return b
+}
+
+// SetEntity adds the given columns and their values from an Entity object to the query.
+func (b *UpdateBuilder) SetEntity(e Entity, cols ...string) *UpdateBuilder {
+	var setBuilder builderFn = func(sw io.StringWriter, args Placeholders) error {
+		if len(cols) == 0 {
+			return errors.New("sqlb: columns must be provided to set an entity")
+		}
+
+		values, err := e.GetValues(cols)
+		if err != nil {
+			return err
+		}
+
+		return setClause{
+			cols:   cols,
+			values: values,
+		}.Build(sw, args)
+	}
+	b.sets = append(b.sets, setBuilder)
+	return b
+}
+
+// FromTable adds tables to the FROM clause, so that columns of other tables can be used in the query.
+func (b *UpdateBuilder) FromTable(tables ...string) *UpdateBuilder {
+	for _, t := range tables {
+		b.From(BaseTable(t))
+	}
+	return b
+}
+
+// From adds tables to the FROM clause, so that columns of other tables can be used in the query.
+func (b *UpdateBuilder) From(tables ...Table) *UpdateBuilder {
+	b.from = append(b.from, tables...)
+	return b
+}
+
+// Where sets the WHERE clause for the query.
+func (b *UpdateBuilder) Where(conds ...Condition) *UpdateBuilder {
+	b.where = whereClause{
+		cond: And(conds...),
+	}
+	return b
+}
+
+// All allows the query to update all rows without a WHERE clause.
+func (b *UpdateBuilder) All() *UpdateBuilder {
+	b.all = true
+	return b
+}
+
+// Returning sets columns returned by the query. Use Query or QueryRow to read them.
+func (b *UpdateBuilder) Returning(cols ...string) *UpdateBuilder {
+	b.returning = cols
+	return b
+}
+
+// AffectedRows sets the variable to store the number of affected rows when executing the query.
+func (b *UpdateBuilder) AffectedRows(affectedRows *int64) *UpdateBuilder {
+	b.affectedRows = affectedRows
+	return b
+}
+
+// SQL compiles all provided data to return an UPDATE query and arguments.
+// It returns an error if there is no WHERE clause unless All is called.
+func (b UpdateBuilder) SQL() (string, []interface{}, error) {
+	if len(b.sets) == 0 {
+		return "", nil, errors.New("sqlb: there must be at least one column to set")
+	}
+
+	if b.where == nil && !b.all {
+		return "", nil, errors.New("sqlb: WHERE clause is required, use All to update all rows")
+	}
+
+	sb := &strings.Builder{}
+	args := argumentList{}
+
+	_, _ = sb.WriteString("UPDATE ")
+	if err := b.table.Build(sb, &args); err != nil {
+		return "", nil, err
+	}
+
+	_, _ = sb.WriteString(" SET ")
+	for i, set := range b.sets {
+		if i > 0 {
+			_, _ = sb.WriteString(", ")
+		}
+		if err := set.Build(sb, &args); err != nil {
+			return "", nil, err
+		}
+	}
+
+	for i, table := range b.from {
+		if i == 0 {
+			_, _ = sb.WriteString(" FROM ")
+		} else {
+			_, _ = sb.WriteString(", ")
+		}
+		if err := table.Build(sb, &args); err != nil {
+			return "", nil, err
+		}
+	}
+
+	if b.where != nil {
+		if err := b.where.Build(sb, &args); err != nil {
+			return "", nil, err
+		}
+	}
+
+	_ = b.returning.Build(sb, &args)
+	return sb.String(), args, nil
+}
+
+// Exec executes the UPDATE query.
+func (b UpdateBuilder) Exec(ctx context.Context) error {
+	sql, args, err := b.SQL()
+	if err != nil {
+		return err
+	}
+
+	return b.db.Exec(ctx, sql, args, b.affectedRows)
+}
+
+// Query executes the UPDATE query and parses returned rows to the given records.
+func (b UpdateBuilder) Query(ctx context.Context, records EntityList) error {
+	sql, args, err := b.SQL()
+	if err != nil {
+		return err
+	}
+
+	return b.returning.query(ctx, b.db, sql, args, records)
+}
+
+// QueryRow executes the UPDATE query and parses the first returned row to the given record.
+func (b UpdateBuilder) QueryRow(ctx context.Context, record Entity) error {
+	sql, args, err := b.SQL()
+	if err != nil {
+		return err
+	}
+
+	return b.returning.queryRow(ctx, b.db, sql, args, record)
+}
+
+type setClause struct {
+	cols   []string
+	values []interface{}
+}
+
+func (c setClause) Build(sw io.StringWriter, aa Placeholders) error {
+	for i, col := range c.cols {
+		if i > 0 {
+			_, _ = sw.WriteString(", ")
+		}
+		_, _ = sw.WriteString(col)
+		_, _ = sw.WriteString(" = ")
+		if err := (placeholder{value: c.values[i]}).Build(sw, aa); err != nil {
+			return err
+		}
+	}
+	return nil
+}
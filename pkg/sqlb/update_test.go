@@ -0,0 +1,201 @@
+package sqlb_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bongnv/pggo/pkg/sqlb"
+)
+
+func Test_Update_SQL(t *testing.T) {
+	t.Run("via table name", func(t *testing.T) {
+		sql, args, err := sqlb.UpdateTable("person").Set("name", "Joe").Where(sqlb.Equal("id", 1)).SQL()
+		require.NoError(t, err)
+		require.Equal(t, "UPDATE person SET name = $1 WHERE (id = $2)", sql)
+		require.Equal(t, []interface{}{"Joe", 1}, args)
+	})
+
+	t.Run("via table", func(t *testing.T) {
+		sql, args, err := sqlb.Update(sqlb.BaseTable("person")).Set("name", "Joe").Set("age", 20).Where(sqlb.Equal("id", 1)).SQL()
+		require.NoError(t, err)
+		require.Equal(t, "UPDATE person SET name = $1, age = $2 WHERE (id = $3)", sql)
+		require.Equal(t, []interface{}{"Joe", 20, 1}, args)
+	})
+
+	t.Run("with map", func(t *testing.T) {
+		sql, args, err := sqlb.UpdateTable("person").
+			SetMap(map[string]interface{}{"name": "Joe", "age": 20}).
+			Where(sqlb.Equal("id", 1)).
+			SQL()
+		require.NoError(t, err)
+		require.Equal(t, "UPDATE person SET age = $1, name = $2 WHERE (id = $3)", sql)
+		require.Equal(t, []interface{}{20, "Joe", 1}, args)
+	})
+
+	t.Run("with entity", func(t *testing.T) {
+		sql, args, err := sqlb.UpdateTable("person").
+			SetEntity(&mockRecord{ID: 1, Name: "Joe"}, "name").
+			Set("age", 20).
+			Where(sqlb.Equal("id", 1)).
+			SQL()
+		require.NoError(t, err)
+		require.Equal(t, "UPDATE person SET name = $1, age = $2 WHERE (id = $3)", sql)
+		require.Equal(t, []interface{}{"Joe", 20, 1}, args)
+	})
+
+	t.Run("with entity without columns", func(t *testing.T) {
+		_, _, err := sqlb.UpdateTable("person").SetEntity(&mockRecord{ID: 1}).Where(sqlb.Equal("id", 1)).SQL()
+		require.EqualError(t, err, "sqlb: columns must be provided to set an entity")
+	})
+
+	t.Run("with non exist column & entity", func(t *testing.T) {
+		_, _, err := sqlb.UpdateTable("person").SetEntity(&mockRecord{ID: 1}, "non_exist").Where(sqlb.Equal("id", 1)).SQL()
+		require.EqualError(t, err, "non_exist couldn't be found")
+	})
+
+	t.Run("with from", func(t *testing.T) {
+		sql, args, err := sqlb.UpdateTable("person").
+			Set("active", false).
+			FromTable("org", "plan").
+			Where(sqlb.EqualColumn("person.org_id", "org.id"), sqlb.EqualColumn("org.plan_id", "plan.id"), sqlb.Equal("plan.name", "free")).
+			SQL()
+		require.NoError(t, err)
+		require.Equal(t, "UPDATE person SET active = $1 FROM org, plan WHERE ((person.org_id = org.id) AND (org.plan_id = plan.id) AND (plan.name = $2))", sql)
+		require.Equal(t, []interface{}{false, "free"}, args)
+	})
+
+	t.Run("without where", func(t *testing.T) {
+		_, _, err := sqlb.UpdateTable("person").Set("active", false).SQL()
+		require.EqualError(t, err, "sqlb: WHERE clause is required, use All to update all rows")
+	})
+
+	t.Run("all rows", func(t *testing.T) {
+		sql, args, err := sqlb.UpdateTable("person").Set("active", false).All().SQL()
+		require.NoError(t, err)
+		require.Equal(t, "UPDATE person SET active = $1", sql)
+		require.Equal(t, []interface{}{false}, args)
+	})
+
+	t.Run("with returning", func(t *testing.T) {
+		sql, args, err := sqlb.UpdateTable("person").
+			Set("name", "Joe").
+			Where(sqlb.Equal("id", 1)).
+			Returning("id", "updated_at").
+			SQL()
+		require.NoError(t, err)
+		require.Equal(t, "UPDATE person SET name = $1 WHERE (id = $2) RETURNING id, updated_at", sql)
+		require.Equal(t, []interface{}{"Joe", 1}, args)
+	})
+
+	t.Run("without columns", func(t *testing.T) {
+		_, _, err := sqlb.UpdateTable("person").Where(sqlb.Equal("id", 1)).SQL()
+		require.EqualError(t, err, "sqlb: there must be at least one column to set")
+	})
+
+	t.Run("with error where", func(t *testing.T) {
+		_, _, err := sqlb.UpdateTable("person").Set("name", "Joe").Where(sqlb.In("id")).SQL()
+		require.EqualError(t, err, "values list must not be empty")
+	})
+
+	t.Run("table with error", func(t *testing.T) {
+		table := tableWithErr{
+			err: errors.New("random error"),
+		}
+		_, _, err := sqlb.Update(table).Set("name", "Joe").All().SQL()
+		require.EqualError(t, err, "random error")
+	})
+
+	t.Run("from table with error", func(t *testing.T) {
+		table := tableWithErr{
+			err: errors.New("random error"),
+		}
+		_, _, err := sqlb.UpdateTable("person").Set("name", "Joe").From(table).All().SQL()
+		require.EqualError(t, err, "random error")
+	})
+}
+
+func Test_Update_Exec(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("without db", func(t *testing.T) {
+		err := sqlb.UpdateTable("person").Set("name", "Joe").All().Exec(ctx)
+		require.EqualError(t, err, "sqlb: no DB was provided to execute the query")
+	})
+
+	t.Run("error when creating SQL", func(t *testing.T) {
+		db := &mockExecer{}
+		err := sqlb.MakeUpdateBuilder(db, "person").Set("name", "Joe").Exec(ctx)
+		require.EqualError(t, err, "sqlb: WHERE clause is required, use All to update all rows")
+		require.False(t, db.called)
+	})
+
+	t.Run("run with no error", func(t *testing.T) {
+		db := &mockExecer{
+			affectedRows: 2,
+		}
+		var affectedRows int64
+		err := sqlb.MakeUpdateBuilder(db, "person").
+			Set("name", "Joe").
+			Where(sqlb.In("id", 1, 2)).
+			AffectedRows(&affectedRows).
+			Exec(ctx)
+		require.NoError(t, err)
+		require.True(t, db.called)
+		require.Equal(t, "UPDATE person SET name = $1 WHERE (id IN ($2,$3))", db.sql)
+		require.Equal(t, []interface{}{"Joe", 1, 2}, db.args)
+		require.EqualValues(t, 2, affectedRows)
+	})
+}
+
+func Test_Update_Query(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("without returning", func(t *testing.T) {
+		db := &mockQueryExecer{}
+		err := sqlb.MakeUpdateBuilder(db, "person").Set("name", "Joe").All().Query(ctx, &mockRecords{})
+		require.EqualError(t, err, "sqlb: Returning must be called to query returned rows")
+	})
+
+	t.Run("error when creating SQL", func(t *testing.T) {
+		db := &mockQueryExecer{}
+		err := sqlb.MakeUpdateBuilder(db, "person").Set("name", "Joe").Returning("id").QueryRow(ctx, &mockRecord{})
+		require.EqualError(t, err, "sqlb: WHERE clause is required, use All to update all rows")
+	})
+
+	t.Run("run with no error", func(t *testing.T) {
+		db := &mockQueryExecer{
+			mockDB: mockDB{
+				data: `[{"id":1},{"id":2}]`,
+			},
+		}
+		records := mockRecords{}
+		err := sqlb.MakeUpdateBuilder(db, "person").
+			Set("name", "Joe").
+			Where(sqlb.In("id", 1, 2)).
+			Returning("id").
+			Query(ctx, &records)
+		require.NoError(t, err)
+		require.Equal(t, "UPDATE person SET name = $1 WHERE (id IN ($2,$3)) RETURNING id", db.mockDB.sql)
+		require.Len(t, records, 2)
+	})
+
+	t.Run("query row with no error", func(t *testing.T) {
+		db := &mockQueryExecer{
+			mockDB: mockDB{
+				data: `{"id":1,"name":"Joe"}`,
+			},
+		}
+		record := mockRecord{}
+		err := sqlb.MakeUpdateBuilder(db, "person").
+			Set("name", "Joe").
+			Where(sqlb.Equal("id", 1)).
+			Returning("id", "name").
+			QueryRow(ctx, &record)
+		require.NoError(t, err)
+		require.Equal(t, "UPDATE person SET name = $1 WHERE (id = $2) RETURNING id, name", db.mockDB.sql)
+		require.Equal(t, "Joe", record.Name)
+	})
+}
@@ -0,0 +1,219 @@
+package sqlb
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DefaultSchema is the schema of tables whose definitions don't specify any.
+const DefaultSchema = "public"
+
+// ColumnDefinition describes a column which code is generated for.
+type ColumnDefinition struct {
+	Name     string
+	DataType string
+	Nullable bool
+}
+
+// TableDefinition describes the structure of a table which code is generated for.
+type TableDefinition struct {
+	// Schema is the schema containing the table, DefaultSchema is used if it's empty.
+	Schema  string
+	Name    string
+	Columns []ColumnDefinition
+	// Fingerprint is the hash of columns when the code was generated, see ColumnsFingerprint.
+	Fingerprint string
+}
+
+// ColumnsFingerprint returns a hash of names, types and nullability of columns regardless of their order.
+func ColumnsFingerprint(cols []ColumnDefinition) string {
+	lines := make([]string, len(cols))
+	for i, c := range cols {
+		lines[i] = fmt.Sprintf("%s %s %t", c.Name, c.DataType, c.Nullable)
+	}
+	sort.Strings(lines)
+
+	sum := sha256.Sum256([]byte(strings.Join(lines, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// qualifiedName returns the name of the table with its schema.
+func (t *TableDefinition) qualifiedName() string {
+	if t.Schema == "" {
+		return DefaultSchema + "." + t.Name
+	}
+
+	return t.Schema + "." + t.Name
+}
+
+// SchemaDriftError is returned by VerifySchema if the live DB schema is different from generated code.
+type SchemaDriftError struct {
+	// Differences contains descriptions of differences, e.g. "users.name: type is text, expected character varying".
+	Differences []string
+}
+
+// Error returns all differences in a message.
+func (e *SchemaDriftError) Error() string {
+	return "sqlb: schema drift detected:\n\t" + strings.Join(e.Differences, "\n\t")
+}
+
+// liveColumnsQuery names enums and arrays by their types the same way as the code generator.
+var liveColumnsQuery = []string{
+	"table_schema",
+	"table_name",
+	"column_name",
+	"is_nullable = 'YES' AS nullable",
+	`CASE data_type
+    WHEN 'USER-DEFINED' THEN udt_name::text
+    WHEN 'ARRAY' THEN pg_catalog.format_type(format('%I.%I', udt_schema, udt_name)::regtype, NULL)
+    ELSE data_type
+  END AS data_type`,
+}
+
+// VerifySchema compares tables with the live DB schema and returns SchemaDriftError listing all differences.
+// Generated packages list their tables in TableDefinitions, e.g. VerifySchema(ctx, db, model.TableDefinitions...).
+// It's useful to be called at the startup of a service to detect code generated against a different schema.
+func VerifySchema(ctx context.Context, db Queryer, tables ...*TableDefinition) error {
+	if len(tables) == 0 {
+		return nil
+	}
+
+	var schemas, names []interface{}
+	seen := map[string]bool{}
+	for _, t := range tables {
+		schema := t.Schema
+		if schema == "" {
+			schema = DefaultSchema
+		}
+
+		if !seen["schema "+schema] {
+			seen["schema "+schema] = true
+			schemas = append(schemas, schema)
+		}
+
+		if !seen["table "+t.Name] {
+			seen["table "+t.Name] = true
+			names = append(names, t.Name)
+		}
+	}
+
+	live := &liveColumnList{}
+	b := &SelectBuilder{
+		cols: columnList(liveColumnsQuery),
+		db:   db,
+	}
+	err := b.FromTable("information_schema.columns").
+		Where(In("table_schema", schemas...), In("table_name", names...)).
+		Query(ctx, live)
+	if err != nil {
+		return err
+	}
+
+	liveTables := map[string][]ColumnDefinition{}
+	for _, c := range *live {
+		name := c.schema + "." + c.table
+		liveTables[name] = append(liveTables[name], c.ColumnDefinition)
+	}
+
+	var differences []string
+	for _, t := range tables {
+		differences = append(differences, compareColumns(t, liveTables[t.qualifiedName()])...)
+	}
+
+	if len(differences) > 0 {
+		return &SchemaDriftError{
+			Differences: differences,
+		}
+	}
+
+	return nil
+}
+
+func compareColumns(t *TableDefinition, live []ColumnDefinition) []string {
+	if len(live) == 0 {
+		return []string{t.Name + ": table doesn't exist"}
+	}
+
+	if ColumnsFingerprint(live) == t.Fingerprint {
+		return nil
+	}
+
+	liveColumns := map[string]ColumnDefinition{}
+	for _, c := range live {
+		liveColumns[c.Name] = c
+	}
+
+	var differences []string
+	expected := map[string]bool{}
+	for _, c := range t.Columns {
+		expected[c.Name] = true
+		liveCol, ok := liveColumns[c.Name]
+		switch {
+		case !ok:
+			differences = append(differences, fmt.Sprintf("%s.%s: column doesn't exist", t.Name, c.Name))
+		case liveCol.DataType != c.DataType:
+			differences = append(differences, fmt.Sprintf("%s.%s: type is %s, expected %s", t.Name, c.Name, liveCol.DataType, c.DataType))
+		case liveCol.Nullable != c.Nullable:
+			differences = append(differences, fmt.Sprintf("%s.%s: nullable is %t, expected %t", t.Name, c.Name, liveCol.Nullable, c.Nullable))
+		}
+	}
+
+	for _, c := range live {
+		if !expected[c.Name] {
+			differences = append(differences, fmt.Sprintf("%s.%s: column isn't in generated code", t.Name, c.Name))
+		}
+	}
+
+	// columns are the same but the fingerprint was generated from different columns
+	if len(differences) == 0 {
+		differences = append(differences, t.Name+": fingerprint doesn't match")
+	}
+
+	return differences
+}
+
+type liveColumn struct {
+	ColumnDefinition
+	schema string
+	table  string
+}
+
+func (c *liveColumn) GetPointers(cols []string) ([]interface{}, error) {
+	pointers := make([]interface{}, len(cols))
+	for i, col := range cols {
+		switch col {
+		case "table_schema":
+			pointers[i] = &c.schema
+		case "table_name":
+			pointers[i] = &c.table
+		case "column_name":
+			pointers[i] = &c.Name
+		case "nullable":
+			pointers[i] = &c.Nullable
+		case "data_type":
+			pointers[i] = &c.DataType
+		default:
+			return nil, fmt.Errorf("sqlb: column %s doesn't exist", col)
+		}
+	}
+
+	return pointers, nil
+}
+
+func (c *liveColumn) GetValues(cols []string) ([]interface{}, error) {
+	return nil, fmt.Errorf("sqlb: liveColumn doesn't support GetValues")
+}
+
+type liveColumnList []*liveColumn
+
+func (l liveColumnList) New() Entity {
+	return &liveColumn{}
+}
+
+func (l *liveColumnList) Append(e Entity) {
+	*l = append(*l, e.(*liveColumn))
+}
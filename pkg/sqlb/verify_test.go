@@ -0,0 +1,141 @@
+package sqlb_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bongnv/pggo/pkg/sqlb"
+)
+
+// columnsDB returns rows of table_schema, table_name, column_name, nullable and data_type.
+type columnsDB struct {
+	mockDB
+	rows [][]interface{}
+	args []interface{}
+}
+
+func (m *columnsDB) Query(ctx context.Context, query string, args []interface{}, records sqlb.EntityList) error {
+	m.sql = query
+	m.args = args
+	for _, row := range m.rows {
+		e := records.New()
+		pointers, err := e.GetPointers([]string{"table_schema", "table_name", "column_name", "nullable", "data_type"})
+		if err != nil {
+			return err
+		}
+
+		*pointers[0].(*string) = row[0].(string)
+		*pointers[1].(*string) = row[1].(string)
+		*pointers[2].(*string) = row[2].(string)
+		*pointers[3].(*bool) = row[3].(bool)
+		*pointers[4].(*string) = row[4].(string)
+		records.Append(e)
+	}
+
+	return m.err
+}
+
+func usersDefinition() *sqlb.TableDefinition {
+	cols := []sqlb.ColumnDefinition{
+		{Name: "id", DataType: "bigint"},
+		{Name: "name", DataType: "text"},
+		{Name: "age", DataType: "integer", Nullable: true},
+	}
+
+	return &sqlb.TableDefinition{
+		Name:        "users",
+		Columns:     cols,
+		Fingerprint: sqlb.ColumnsFingerprint(cols),
+	}
+}
+
+func Test_VerifySchema(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("same schema", func(t *testing.T) {
+		db := &columnsDB{
+			rows: [][]interface{}{
+				{"public", "users", "age", true, "integer"},
+				{"public", "users", "id", false, "bigint"},
+				{"public", "users", "name", false, "text"},
+			},
+		}
+		require.NoError(t, sqlb.VerifySchema(ctx, db, usersDefinition()))
+		require.Contains(t, db.sql, "FROM information_schema.columns WHERE ((table_schema IN ($1)) AND (table_name IN ($2)))")
+		require.Equal(t, []interface{}{"public", "users"}, db.args)
+	})
+
+	t.Run("drift", func(t *testing.T) {
+		db := &columnsDB{
+			rows: [][]interface{}{
+				{"public", "users", "id", false, "bigint"},
+				{"public", "users", "name", false, "character varying"},
+				{"public", "users", "email", true, "text"},
+			},
+		}
+		err := sqlb.VerifySchema(ctx, db, usersDefinition(), &sqlb.TableDefinition{Name: "orgs"})
+		require.EqualError(t, err, "sqlb: schema drift detected:\n"+
+			"\tusers.name: type is character varying, expected text\n"+
+			"\tusers.age: column doesn't exist\n"+
+			"\tusers.email: column isn't in generated code\n"+
+			"\torgs: table doesn't exist")
+
+		var driftErr *sqlb.SchemaDriftError
+		require.True(t, errors.As(err, &driftErr))
+		require.Len(t, driftErr.Differences, 4)
+	})
+
+	t.Run("nullability", func(t *testing.T) {
+		db := &columnsDB{
+			rows: [][]interface{}{
+				{"public", "users", "age", false, "integer"},
+				{"public", "users", "id", false, "bigint"},
+				{"public", "users", "name", false, "text"},
+			},
+		}
+		err := sqlb.VerifySchema(ctx, db, usersDefinition())
+		require.EqualError(t, err, "sqlb: schema drift detected:\n\tusers.age: nullable is false, expected true")
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		db := &columnsDB{
+			mockDB: mockDB{err: errors.New("random error")},
+		}
+		require.EqualError(t, sqlb.VerifySchema(ctx, db, usersDefinition()), "random error")
+	})
+
+	t.Run("no tables", func(t *testing.T) {
+		db := &columnsDB{}
+		require.NoError(t, sqlb.VerifySchema(ctx, db))
+		require.Empty(t, db.sql)
+	})
+
+	t.Run("other schemas", func(t *testing.T) {
+		db := &columnsDB{
+			rows: [][]interface{}{
+				{"public", "users", "id", false, "bigint"},
+				{"app", "users", "age", true, "integer"},
+				{"app", "users", "id", false, "bigint"},
+				{"app", "users", "name", false, "text"},
+			},
+		}
+		users := usersDefinition()
+		users.Schema = "app"
+		orgs := &sqlb.TableDefinition{Name: "orgs"}
+		err := sqlb.VerifySchema(ctx, db, users, orgs)
+		require.EqualError(t, err, "sqlb: schema drift detected:\n\torgs: table doesn't exist")
+		require.Equal(t, []interface{}{"app", "public", "users", "orgs"}, db.args)
+	})
+}
+
+func Test_ColumnsFingerprint(t *testing.T) {
+	cols := usersDefinition().Columns
+	reversed := []sqlb.ColumnDefinition{cols[2], cols[1], cols[0]}
+	require.Equal(t, sqlb.ColumnsFingerprint(cols), sqlb.ColumnsFingerprint(reversed))
+
+	cols[0].Nullable = true
+	require.NotEqual(t, sqlb.ColumnsFingerprint(cols), sqlb.ColumnsFingerprint(reversed))
+}
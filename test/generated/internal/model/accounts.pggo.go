@@ -0,0 +1,92 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bongnv/pggo/pkg/sqlb"
+)
+
+// Accounts represents accounts table.
+type Accounts struct {
+	CreatedAt time.Time `json:"createdAt" db:"created_at"`
+	ID        int32     `json:"id" db:"id"`
+	Name      string    `json:"name" db:"name"`
+	Status    string    `json:"status" db:"status"`
+}
+
+// GetPointers returns pointers for storing data from the given columns.
+func (e *Accounts) GetPointers(cols []string) ([]interface{}, error) {
+	if len(cols) == 0 {
+		return []interface{}{&e.CreatedAt, &e.ID, &e.Name, &e.Status}, nil
+	}
+
+	pointers := make([]interface{}, len(cols))
+	for i, col := range cols {
+		switch col {
+		case "created_at":
+			pointers[i] = &e.CreatedAt
+		case "id":
+			pointers[i] = &e.ID
+		case "name":
+			pointers[i] = &e.Name
+		case "status":
+			pointers[i] = &e.Status
+		default:
+			return nil, fmt.Errorf("Accounts: column %s doesn't exist", col)
+		}
+	}
+
+	return pointers, nil
+}
+
+// GetValues returns values of the given columns.
+func (e *Accounts) GetValues(cols []string) ([]interface{}, error) {
+	if len(cols) == 0 {
+		return []interface{}{e.CreatedAt, e.ID, e.Name, e.Status}, nil
+	}
+
+	values := make([]interface{}, len(cols))
+	for i, col := range cols {
+		switch col {
+		case "created_at":
+			values[i] = e.CreatedAt
+		case "id":
+			values[i] = e.ID
+		case "name":
+			values[i] = e.Name
+		case "status":
+			values[i] = e.Status
+		default:
+			return nil, fmt.Errorf("Accounts: column %s doesn't exist", col)
+		}
+	}
+
+	return values, nil
+}
+
+// AccountsList represents a list of Accounts.
+type AccountsList []*Accounts
+
+// New creates a new Accounts.
+func (l AccountsList) New() sqlb.Entity {
+	return &Accounts{}
+}
+
+// Append adds a Accounts into the list.
+func (l *AccountsList) Append(e sqlb.Entity) {
+	*l = append(*l, e.(*Accounts))
+}
+
+// AccountsDefinition is the structure of accounts table when the code was generated.
+// It's listed in TableDefinitions to be verified by sqlb.VerifySchema.
+var AccountsDefinition = &sqlb.TableDefinition{
+	Name:        "accounts",
+	Fingerprint: "981e06d4601a44ddb03a76d898c9761d51d1390a6d9730e60746e4a553737376",
+	Columns: []sqlb.ColumnDefinition{
+		{Name: "created_at", DataType: "timestamp with time zone"},
+		{Name: "id", DataType: "integer"},
+		{Name: "name", DataType: "text"},
+		{Name: "status", DataType: "text"},
+	},
+}
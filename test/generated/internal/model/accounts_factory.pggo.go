@@ -0,0 +1,117 @@
+package model
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/bongnv/pggo/pkg/fake"
+
+	"github.com/bongnv/pggo/pkg/sqlb"
+)
+
+// AccountsFactory builds Accounts entities with deterministic fake values and inserts them for tests.
+// Nullable columns are left NULL, they can be set via overrides.
+// Columns with defaults are left to the database unless they're set by overrides, they're read back after inserting.
+type AccountsFactory struct {
+	seq     int64
+	factory sqlb.Factory
+}
+
+// NewAccountsFactory creates a AccountsFactory inserting entities via the given factory.
+func NewAccountsFactory(factory sqlb.Factory) *AccountsFactory {
+	return &AccountsFactory{
+		factory: factory,
+	}
+}
+
+// Build returns a new Accounts with fake values, then applies overrides in order. It doesn't insert the entity.
+func (f *AccountsFactory) Build(overrides ...func(e *Accounts)) *Accounts {
+	n := atomic.AddInt64(&f.seq, 1)
+	e := &Accounts{
+		Name: fake.String("name", n, 0),
+	}
+
+	for _, override := range overrides {
+		override(e)
+	}
+
+	return e
+}
+
+// Create builds a new Accounts and inserts it.
+func (f *AccountsFactory) Create(ctx context.Context, overrides ...func(e *Accounts)) (*Accounts, error) {
+	entities, err := f.CreateMany(ctx, 1, overrides...)
+	if err != nil {
+		return nil, err
+	}
+
+	return entities[0], nil
+}
+
+// CreateMany builds count Accounts entities and inserts them in a single query.
+func (f *AccountsFactory) CreateMany(ctx context.Context, count int, overrides ...func(e *Accounts)) ([]*Accounts, error) {
+	if count <= 0 {
+		return nil, nil
+	}
+
+	entities := make([]*Accounts, count)
+	insert := f.factory.InsertTable("accounts").
+		Columns("created_at", "id", "name", "status")
+	for i := range entities {
+		e := f.Build(overrides...)
+
+		values, err := e.GetValues(nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if fake.IsZero(e.CreatedAt) {
+			values[0] = sqlb.Default
+		}
+		if fake.IsZero(e.ID) {
+			values[1] = sqlb.Default
+		}
+		if fake.IsZero(e.Status) {
+			values[3] = sqlb.Default
+		}
+
+		insert.Values(values...)
+		entities[i] = e
+	}
+
+	err := insert.
+		Returning("created_at", "id", "status").
+		Query(ctx, &accountsInserted{entities: entities})
+	if err != nil {
+		return nil, err
+	}
+
+	return entities, nil
+}
+
+// accountsInserted reads returned rows back into inserted entities in order.
+// Only columns which were left to the database are updated.
+type accountsInserted struct {
+	entities []*Accounts
+	next     int
+}
+
+func (l *accountsInserted) New() sqlb.Entity {
+	return &Accounts{}
+}
+
+func (l *accountsInserted) Append(e sqlb.Entity) {
+	returned := e.(*Accounts)
+	entity := l.entities[l.next]
+	l.next++
+
+	if fake.IsZero(entity.CreatedAt) {
+		entity.CreatedAt = returned.CreatedAt
+	}
+	if fake.IsZero(entity.ID) {
+		entity.ID = returned.ID
+	}
+	if fake.IsZero(entity.Status) {
+		entity.Status = returned.Status
+	}
+}
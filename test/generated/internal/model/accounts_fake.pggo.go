@@ -0,0 +1,147 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/bongnv/pggo/pkg/sqlb"
+)
+
+// InMemoryAccountsRepository is a thread-safe in-memory implementation of AccountsRepository.
+// It enforces the primary key and unique constraints of accounts table.
+type InMemoryAccountsRepository struct {
+	mu   sync.RWMutex
+	rows []*Accounts
+}
+
+// NewInMemoryAccountsRepository creates an empty InMemoryAccountsRepository.
+func NewInMemoryAccountsRepository() *InMemoryAccountsRepository {
+	return &InMemoryAccountsRepository{}
+}
+
+// Get returns the Accounts with the given primary key.
+func (r *InMemoryAccountsRepository) Get(_ context.Context, id int32) (*Accounts, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	i := r.indexOf(accountsPrimaryKey(id))
+	if i < 0 {
+		return nil, sqlb.ErrNotFound
+	}
+
+	return accountsClone(r.rows[i]), nil
+}
+
+// List returns all Accounts entities in insertion order.
+func (r *InMemoryAccountsRepository) List(_ context.Context) ([]*Accounts, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rows := make([]*Accounts, len(r.rows))
+	for i, row := range r.rows {
+		rows[i] = accountsClone(row)
+	}
+
+	return rows, nil
+}
+
+// Create inserts a new Accounts. It returns sqlb.ErrDuplicateKey if a constraint is violated.
+func (r *InMemoryAccountsRepository) Create(_ context.Context, e *Accounts) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkConstraints(e, -1); err != nil {
+		return err
+	}
+
+	r.rows = append(r.rows, accountsClone(e))
+	return nil
+}
+
+// Update updates the Accounts with the same primary key. It returns sqlb.ErrDuplicateKey if a constraint is violated.
+func (r *InMemoryAccountsRepository) Update(_ context.Context, e *Accounts) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := r.indexOf(accountsPrimaryKey(e.ID))
+	if i < 0 {
+		return sqlb.ErrNotFound
+	}
+
+	if err := r.checkConstraints(e, i); err != nil {
+		return err
+	}
+
+	r.rows[i] = accountsClone(e)
+	return nil
+}
+
+// Delete deletes the Accounts with the given primary key.
+func (r *InMemoryAccountsRepository) Delete(_ context.Context, id int32) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := r.indexOf(accountsPrimaryKey(id))
+	if i < 0 {
+		return sqlb.ErrNotFound
+	}
+
+	r.rows = append(r.rows[:i], r.rows[i+1:]...)
+	return nil
+}
+
+func (r *InMemoryAccountsRepository) indexOf(key string) int {
+	for i, row := range r.rows {
+		if accountsPrimaryKey(row.ID) == key {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// checkConstraints returns an error if e conflicts with any existing row except the one at index skip.
+func (r *InMemoryAccountsRepository) checkConstraints(e *Accounts, skip int) error {
+	keys := accountsUniqueKeys(e)
+	for i, row := range r.rows {
+		if i == skip {
+			continue
+		}
+
+		rowKeys := accountsUniqueKeys(row)
+		for j, key := range keys {
+			if key.value != "" && key.value == rowKeys[j].value {
+				return fmt.Errorf("%w: %s", sqlb.ErrDuplicateKey, key.constraint)
+			}
+		}
+	}
+
+	return nil
+}
+
+func accountsPrimaryKey(id int32) string {
+	return fmt.Sprintf("%#v", []interface{}{id})
+}
+
+// accountsClone returns a deep copy of an entity, so that stored rows don't share pointers with callers.
+func accountsClone(e *Accounts) *Accounts {
+	row := *e
+
+	return &row
+}
+
+type accountsUniqueKey struct {
+	constraint string
+	value      string
+}
+
+// accountsUniqueKeys returns values of all unique keys of an entity.
+// A key containing NULL has an empty value as NULLs never conflict.
+func accountsUniqueKeys(e *Accounts) []accountsUniqueKey {
+	keys := []accountsUniqueKey{
+		{constraint: "accounts_pkey", value: accountsPrimaryKey(e.ID)},
+	}
+
+	return keys
+}
@@ -0,0 +1,119 @@
+package model
+
+import (
+	"context"
+
+	"github.com/bongnv/pggo/pkg/sqlb"
+)
+
+// AccountsRepository provides CRUD operations for accounts table.
+type AccountsRepository interface {
+	// Get returns the Accounts with the given primary key. It returns sqlb.ErrNotFound if there is no such entity.
+	Get(ctx context.Context, id int32) (*Accounts, error)
+	// List returns all Accounts entities.
+	List(ctx context.Context) ([]*Accounts, error)
+	// Create inserts a new Accounts. It returns sqlb.ErrDuplicateKey if a unique constraint is violated.
+	// Columns with defaults are left to the database if they're empty, they're read back into e after inserting.
+	Create(ctx context.Context, e *Accounts) error
+	// Update updates the Accounts with the same primary key. It returns sqlb.ErrNotFound if there is no such entity
+	// and sqlb.ErrDuplicateKey if a unique constraint is violated.
+	Update(ctx context.Context, e *Accounts) error
+	// Delete deletes the Accounts with the given primary key. It returns sqlb.ErrNotFound if there is no such entity.
+	Delete(ctx context.Context, id int32) error
+}
+
+// NewAccountsRepository creates a AccountsRepository backed by the given factory.
+func NewAccountsRepository(factory sqlb.Factory) AccountsRepository {
+	return accountsRepository{
+		factory: factory,
+	}
+}
+
+var accountsColumns = []string{"created_at", "id", "name", "status"}
+
+const accountsUpdateSQL = `UPDATE accounts SET created_at = $1, name = $2, status = $3 WHERE id = $4`
+
+const accountsDeleteSQL = `DELETE FROM accounts WHERE id = $1`
+
+type accountsRepository struct {
+	factory sqlb.Factory
+}
+
+// Get returns the Accounts with the given primary key.
+func (r accountsRepository) Get(ctx context.Context, id int32) (*Accounts, error) {
+	var rows AccountsList
+	err := r.factory.Select(accountsColumns...).
+		FromTable("accounts").
+		Where(
+			sqlb.Equal("id", id),
+		).
+		Query(ctx, &rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, sqlb.ErrNotFound
+	}
+
+	return rows[0], nil
+}
+
+// List returns all Accounts entities.
+func (r accountsRepository) List(ctx context.Context) ([]*Accounts, error) {
+	var rows AccountsList
+	if err := r.factory.Select(accountsColumns...).FromTable("accounts").Query(ctx, &rows); err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// Create inserts a new Accounts.
+func (r accountsRepository) Create(ctx context.Context, e *Accounts) error {
+	values, err := e.GetValues(nil)
+	if err != nil {
+		return err
+	}
+
+	values[0] = sqlb.DefaultIfZero(values[0])
+	values[1] = sqlb.DefaultIfZero(values[1])
+	values[3] = sqlb.DefaultIfZero(values[3])
+
+	err = r.factory.InsertTable("accounts").
+		Columns(accountsColumns...).
+		Values(values...).
+		Returning("created_at", "id", "status").
+		QueryRow(ctx, e)
+	return sqlb.WrapDuplicateKey(err)
+}
+
+// Update updates the Accounts with the same primary key.
+func (r accountsRepository) Update(ctx context.Context, e *Accounts) error {
+	args := []interface{}{
+		e.CreatedAt,
+		e.Name,
+		e.Status,
+		e.ID,
+	}
+
+	return sqlb.WrapDuplicateKey(r.exec(ctx, accountsUpdateSQL, args))
+}
+
+// Delete deletes the Accounts with the given primary key.
+func (r accountsRepository) Delete(ctx context.Context, id int32) error {
+	return r.exec(ctx, accountsDeleteSQL, []interface{}{id})
+}
+
+func (r accountsRepository) exec(ctx context.Context, sql string, args []interface{}) error {
+	var affectedRows int64
+	if err := r.factory.DB.Exec(ctx, sql, args, &affectedRows); err != nil {
+		return err
+	}
+
+	if affectedRows == 0 {
+		return sqlb.ErrNotFound
+	}
+
+	return nil
+}
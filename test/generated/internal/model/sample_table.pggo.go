@@ -1,11 +1,79 @@
 package model
 
 import (
-	"github.com/google/uuid"
+	"fmt"
+
+	"github.com/bongnv/pggo/pkg/sqlb"
 )
 
 // SampleTable represents sample_table table.
 type SampleTable struct {
-	ID   uuid.UUID
-	Name string
+	ID   int32  `json:"id" db:"id"`
+	Name string `json:"name" db:"name"`
+}
+
+// GetPointers returns pointers for storing data from the given columns.
+func (e *SampleTable) GetPointers(cols []string) ([]interface{}, error) {
+	if len(cols) == 0 {
+		return []interface{}{&e.ID, &e.Name}, nil
+	}
+
+	pointers := make([]interface{}, len(cols))
+	for i, col := range cols {
+		switch col {
+		case "id":
+			pointers[i] = &e.ID
+		case "name":
+			pointers[i] = &e.Name
+		default:
+			return nil, fmt.Errorf("SampleTable: column %s doesn't exist", col)
+		}
+	}
+
+	return pointers, nil
+}
+
+// GetValues returns values of the given columns.
+func (e *SampleTable) GetValues(cols []string) ([]interface{}, error) {
+	if len(cols) == 0 {
+		return []interface{}{e.ID, e.Name}, nil
+	}
+
+	values := make([]interface{}, len(cols))
+	for i, col := range cols {
+		switch col {
+		case "id":
+			values[i] = e.ID
+		case "name":
+			values[i] = e.Name
+		default:
+			return nil, fmt.Errorf("SampleTable: column %s doesn't exist", col)
+		}
+	}
+
+	return values, nil
+}
+
+// SampleTableList represents a list of SampleTable.
+type SampleTableList []*SampleTable
+
+// New creates a new SampleTable.
+func (l SampleTableList) New() sqlb.Entity {
+	return &SampleTable{}
+}
+
+// Append adds a SampleTable into the list.
+func (l *SampleTableList) Append(e sqlb.Entity) {
+	*l = append(*l, e.(*SampleTable))
+}
+
+// SampleTableDefinition is the structure of sample_table table when the code was generated.
+// It's listed in TableDefinitions to be verified by sqlb.VerifySchema.
+var SampleTableDefinition = &sqlb.TableDefinition{
+	Name:        "sample_table",
+	Fingerprint: "ff6e728ab1c1c904b05f90874febf0b2583bfaf5c27c48b817ff027b9d3a57bf",
+	Columns: []sqlb.ColumnDefinition{
+		{Name: "id", DataType: "integer"},
+		{Name: "name", DataType: "text"},
+	},
 }
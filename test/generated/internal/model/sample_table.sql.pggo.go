@@ -0,0 +1,100 @@
+package model
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bongnv/pggo/pkg/sqlb"
+)
+
+const getSampleTableSQL = `SELECT id, name FROM sample_table WHERE id = $1`
+
+// GetSampleTableParams contains parameters of GetSampleTable.
+type GetSampleTableParams struct {
+	ID int32
+}
+
+// GetSampleTableRow represents a row returned by GetSampleTable.
+type GetSampleTableRow struct {
+	ID   int32
+	Name string
+}
+
+// GetPointers returns pointers for storing data from the given columns.
+func (e *GetSampleTableRow) GetPointers(cols []string) ([]interface{}, error) {
+	if len(cols) == 0 {
+		return []interface{}{&e.ID, &e.Name}, nil
+	}
+
+	pointers := make([]interface{}, len(cols))
+	for i, col := range cols {
+		switch col {
+		case "id":
+			pointers[i] = &e.ID
+		case "name":
+			pointers[i] = &e.Name
+		default:
+			return nil, fmt.Errorf("GetSampleTableRow: column %s doesn't exist", col)
+		}
+	}
+
+	return pointers, nil
+}
+
+// GetValues returns values of the given columns.
+func (e *GetSampleTableRow) GetValues(cols []string) ([]interface{}, error) {
+	if len(cols) == 0 {
+		return []interface{}{e.ID, e.Name}, nil
+	}
+
+	values := make([]interface{}, len(cols))
+	for i, col := range cols {
+		switch col {
+		case "id":
+			values[i] = e.ID
+		case "name":
+			values[i] = e.Name
+		default:
+			return nil, fmt.Errorf("GetSampleTableRow: column %s doesn't exist", col)
+		}
+	}
+
+	return values, nil
+}
+
+type getSampleTableRows []*GetSampleTableRow
+
+func (l getSampleTableRows) New() sqlb.Entity {
+	return &GetSampleTableRow{}
+}
+
+func (l *getSampleTableRows) Append(e sqlb.Entity) {
+	*l = append(*l, e.(*GetSampleTableRow))
+}
+
+// GetSampleTable runs the GetSampleTable query and returns a single row.
+func GetSampleTable(ctx context.Context, db sqlb.DB, params GetSampleTableParams) (*GetSampleTableRow, error) {
+	row := &GetSampleTableRow{}
+	if err := db.QueryRow(ctx, getSampleTableSQL, []interface{}{params.ID}, row); err != nil {
+		return nil, err
+	}
+
+	return row, nil
+}
+
+const deleteSampleTablesSQL = `DELETE FROM sample_table WHERE name = $1`
+
+// DeleteSampleTablesParams contains parameters of DeleteSampleTables.
+type DeleteSampleTablesParams struct {
+	Name string
+}
+
+// DeleteSampleTables runs the DeleteSampleTables query and returns the number of affected rows.
+func DeleteSampleTables(ctx context.Context, db sqlb.DB, params DeleteSampleTablesParams) (int64, error) {
+	var affectedRows int64
+	if err := db.Exec(ctx, deleteSampleTablesSQL, []interface{}{params.Name}, &affectedRows); err != nil {
+		return 0, err
+	}
+
+	return affectedRows, nil
+}
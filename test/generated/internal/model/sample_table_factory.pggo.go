@@ -0,0 +1,75 @@
+package model
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/bongnv/pggo/pkg/fake"
+
+	"github.com/bongnv/pggo/pkg/sqlb"
+)
+
+// SampleTableFactory builds SampleTable entities with deterministic fake values and inserts them for tests.
+// Nullable columns are left NULL, they can be set via overrides.
+// Keys are derived from fake.Seq, so they don't conflict with existing rows.
+type SampleTableFactory struct {
+	seq     int64
+	factory sqlb.Factory
+}
+
+// NewSampleTableFactory creates a SampleTableFactory inserting entities via the given factory.
+func NewSampleTableFactory(factory sqlb.Factory) *SampleTableFactory {
+	return &SampleTableFactory{
+		factory: factory,
+	}
+}
+
+// Build returns a new SampleTable with fake values, then applies overrides in order. It doesn't insert the entity.
+func (f *SampleTableFactory) Build(overrides ...func(e *SampleTable)) *SampleTable {
+	n := atomic.AddInt64(&f.seq, 1)
+	k := fake.Seq()
+	e := &SampleTable{
+		ID:   fake.Int32(k),
+		Name: fake.String("name", n, 0),
+	}
+
+	for _, override := range overrides {
+		override(e)
+	}
+
+	return e
+}
+
+// Create builds a new SampleTable and inserts it.
+func (f *SampleTableFactory) Create(ctx context.Context, overrides ...func(e *SampleTable)) (*SampleTable, error) {
+	entities, err := f.CreateMany(ctx, 1, overrides...)
+	if err != nil {
+		return nil, err
+	}
+
+	return entities[0], nil
+}
+
+// CreateMany builds count SampleTable entities and inserts them in a single query.
+func (f *SampleTableFactory) CreateMany(ctx context.Context, count int, overrides ...func(e *SampleTable)) ([]*SampleTable, error) {
+	if count <= 0 {
+		return nil, nil
+	}
+
+	entities := make([]*SampleTable, count)
+	rows := make([]sqlb.Entity, count)
+	for i := range entities {
+		entities[i] = f.Build(overrides...)
+		rows[i] = entities[i]
+	}
+
+	err := f.factory.InsertTable("sample_table").
+		Columns("id", "name").
+		Entities(rows...).
+		Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return entities, nil
+}
@@ -0,0 +1,147 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/bongnv/pggo/pkg/sqlb"
+)
+
+// InMemorySampleTableRepository is a thread-safe in-memory implementation of SampleTableRepository.
+// It enforces the primary key and unique constraints of sample_table table.
+type InMemorySampleTableRepository struct {
+	mu   sync.RWMutex
+	rows []*SampleTable
+}
+
+// NewInMemorySampleTableRepository creates an empty InMemorySampleTableRepository.
+func NewInMemorySampleTableRepository() *InMemorySampleTableRepository {
+	return &InMemorySampleTableRepository{}
+}
+
+// Get returns the SampleTable with the given primary key.
+func (r *InMemorySampleTableRepository) Get(_ context.Context, id int32) (*SampleTable, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	i := r.indexOf(sampleTablePrimaryKey(id))
+	if i < 0 {
+		return nil, sqlb.ErrNotFound
+	}
+
+	return sampleTableClone(r.rows[i]), nil
+}
+
+// List returns all SampleTable entities in insertion order.
+func (r *InMemorySampleTableRepository) List(_ context.Context) ([]*SampleTable, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rows := make([]*SampleTable, len(r.rows))
+	for i, row := range r.rows {
+		rows[i] = sampleTableClone(row)
+	}
+
+	return rows, nil
+}
+
+// Create inserts a new SampleTable. It returns sqlb.ErrDuplicateKey if a constraint is violated.
+func (r *InMemorySampleTableRepository) Create(_ context.Context, e *SampleTable) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkConstraints(e, -1); err != nil {
+		return err
+	}
+
+	r.rows = append(r.rows, sampleTableClone(e))
+	return nil
+}
+
+// Update updates the SampleTable with the same primary key. It returns sqlb.ErrDuplicateKey if a constraint is violated.
+func (r *InMemorySampleTableRepository) Update(_ context.Context, e *SampleTable) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := r.indexOf(sampleTablePrimaryKey(e.ID))
+	if i < 0 {
+		return sqlb.ErrNotFound
+	}
+
+	if err := r.checkConstraints(e, i); err != nil {
+		return err
+	}
+
+	r.rows[i] = sampleTableClone(e)
+	return nil
+}
+
+// Delete deletes the SampleTable with the given primary key.
+func (r *InMemorySampleTableRepository) Delete(_ context.Context, id int32) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := r.indexOf(sampleTablePrimaryKey(id))
+	if i < 0 {
+		return sqlb.ErrNotFound
+	}
+
+	r.rows = append(r.rows[:i], r.rows[i+1:]...)
+	return nil
+}
+
+func (r *InMemorySampleTableRepository) indexOf(key string) int {
+	for i, row := range r.rows {
+		if sampleTablePrimaryKey(row.ID) == key {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// checkConstraints returns an error if e conflicts with any existing row except the one at index skip.
+func (r *InMemorySampleTableRepository) checkConstraints(e *SampleTable, skip int) error {
+	keys := sampleTableUniqueKeys(e)
+	for i, row := range r.rows {
+		if i == skip {
+			continue
+		}
+
+		rowKeys := sampleTableUniqueKeys(row)
+		for j, key := range keys {
+			if key.value != "" && key.value == rowKeys[j].value {
+				return fmt.Errorf("%w: %s", sqlb.ErrDuplicateKey, key.constraint)
+			}
+		}
+	}
+
+	return nil
+}
+
+func sampleTablePrimaryKey(id int32) string {
+	return fmt.Sprintf("%#v", []interface{}{id})
+}
+
+// sampleTableClone returns a deep copy of an entity, so that stored rows don't share pointers with callers.
+func sampleTableClone(e *SampleTable) *SampleTable {
+	row := *e
+
+	return &row
+}
+
+type sampleTableUniqueKey struct {
+	constraint string
+	value      string
+}
+
+// sampleTableUniqueKeys returns values of all unique keys of an entity.
+// A key containing NULL has an empty value as NULLs never conflict.
+func sampleTableUniqueKeys(e *SampleTable) []sampleTableUniqueKey {
+	keys := []sampleTableUniqueKey{
+		{constraint: "sample_table_pkey", value: sampleTablePrimaryKey(e.ID)},
+	}
+
+	return keys
+}
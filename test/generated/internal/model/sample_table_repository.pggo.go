@@ -0,0 +1,103 @@
+package model
+
+import (
+	"context"
+
+	"github.com/bongnv/pggo/pkg/sqlb"
+)
+
+// SampleTableRepository provides CRUD operations for sample_table table.
+type SampleTableRepository interface {
+	// Get returns the SampleTable with the given primary key. It returns sqlb.ErrNotFound if there is no such entity.
+	Get(ctx context.Context, id int32) (*SampleTable, error)
+	// List returns all SampleTable entities.
+	List(ctx context.Context) ([]*SampleTable, error)
+	// Create inserts a new SampleTable. It returns sqlb.ErrDuplicateKey if a unique constraint is violated.
+	Create(ctx context.Context, e *SampleTable) error
+	// Update updates the SampleTable with the same primary key. It returns sqlb.ErrNotFound if there is no such entity
+	// and sqlb.ErrDuplicateKey if a unique constraint is violated.
+	Update(ctx context.Context, e *SampleTable) error
+	// Delete deletes the SampleTable with the given primary key. It returns sqlb.ErrNotFound if there is no such entity.
+	Delete(ctx context.Context, id int32) error
+}
+
+// NewSampleTableRepository creates a SampleTableRepository backed by the given factory.
+func NewSampleTableRepository(factory sqlb.Factory) SampleTableRepository {
+	return sampleTableRepository{
+		factory: factory,
+	}
+}
+
+var sampleTableColumns = []string{"id", "name"}
+
+const sampleTableUpdateSQL = `UPDATE sample_table SET name = $1 WHERE id = $2`
+
+const sampleTableDeleteSQL = `DELETE FROM sample_table WHERE id = $1`
+
+type sampleTableRepository struct {
+	factory sqlb.Factory
+}
+
+// Get returns the SampleTable with the given primary key.
+func (r sampleTableRepository) Get(ctx context.Context, id int32) (*SampleTable, error) {
+	var rows SampleTableList
+	err := r.factory.Select(sampleTableColumns...).
+		FromTable("sample_table").
+		Where(
+			sqlb.Equal("id", id),
+		).
+		Query(ctx, &rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, sqlb.ErrNotFound
+	}
+
+	return rows[0], nil
+}
+
+// List returns all SampleTable entities.
+func (r sampleTableRepository) List(ctx context.Context) ([]*SampleTable, error) {
+	var rows SampleTableList
+	if err := r.factory.Select(sampleTableColumns...).FromTable("sample_table").Query(ctx, &rows); err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// Create inserts a new SampleTable.
+func (r sampleTableRepository) Create(ctx context.Context, e *SampleTable) error {
+	err := r.factory.InsertTable("sample_table").Columns(sampleTableColumns...).Entities(e).Exec(ctx)
+	return sqlb.WrapDuplicateKey(err)
+}
+
+// Update updates the SampleTable with the same primary key.
+func (r sampleTableRepository) Update(ctx context.Context, e *SampleTable) error {
+	args := []interface{}{
+		e.Name,
+		e.ID,
+	}
+
+	return sqlb.WrapDuplicateKey(r.exec(ctx, sampleTableUpdateSQL, args))
+}
+
+// Delete deletes the SampleTable with the given primary key.
+func (r sampleTableRepository) Delete(ctx context.Context, id int32) error {
+	return r.exec(ctx, sampleTableDeleteSQL, []interface{}{id})
+}
+
+func (r sampleTableRepository) exec(ctx context.Context, sql string, args []interface{}) error {
+	var affectedRows int64
+	if err := r.factory.DB.Exec(ctx, sql, args, &affectedRows); err != nil {
+		return err
+	}
+
+	if affectedRows == 0 {
+		return sqlb.ErrNotFound
+	}
+
+	return nil
+}
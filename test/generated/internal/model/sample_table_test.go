@@ -1,11 +1,14 @@
 package model_test
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/bongnv/pggo/pkg/sqlb"
+	"github.com/bongnv/pggo/test/generated/internal/model"
 	"github.com/bongnv/pggo/test/generated/internal/model/schema"
 )
 
@@ -16,3 +19,73 @@ func Test_SampleTable(t *testing.T) {
 	require.Empty(t, args)
 	require.Equal(t, "SELECT id, name FROM sample_table", sql)
 }
+
+func Test_TableDefinitions(t *testing.T) {
+	require.Equal(t, []*sqlb.TableDefinition{model.SampleTableDefinition, model.AccountsDefinition}, model.TableDefinitions)
+}
+
+func Test_InMemorySampleTableRepository(t *testing.T) {
+	ctx := context.Background()
+	var repo model.SampleTableRepository = model.NewInMemorySampleTableRepository()
+
+	require.NoError(t, repo.Create(ctx, &model.SampleTable{ID: 1, Name: "One"}))
+	err := repo.Create(ctx, &model.SampleTable{ID: 1, Name: "Another"})
+	require.True(t, errors.Is(err, sqlb.ErrDuplicateKey))
+	require.EqualError(t, err, "sqlb: duplicate key value violates unique constraint: sample_table_pkey")
+
+	require.NoError(t, repo.Update(ctx, &model.SampleTable{ID: 1, Name: "Updated"}))
+	record, err := repo.Get(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, "Updated", record.Name)
+
+	require.Equal(t, sqlb.ErrNotFound, repo.Update(ctx, &model.SampleTable{ID: 2}))
+
+	records, err := repo.List(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []*model.SampleTable{{ID: 1, Name: "Updated"}}, records)
+
+	require.NoError(t, repo.Delete(ctx, 1))
+	require.Equal(t, sqlb.ErrNotFound, repo.Delete(ctx, 1))
+	_, err = repo.Get(ctx, 1)
+	require.Equal(t, sqlb.ErrNotFound, err)
+}
+
+type execRecorder struct {
+	sqlb.DB
+	sql  string
+	args []interface{}
+}
+
+func (r *execRecorder) Exec(_ context.Context, sql string, args []interface{}, _ *int64) error {
+	r.sql, r.args = sql, args
+	return nil
+}
+
+func Test_SampleTableFactory(t *testing.T) {
+	db := &execRecorder{}
+	factory := model.NewSampleTableFactory(sqlb.Factory{DB: db})
+
+	// values are derived from the sequence of the factory, IDs from a sequence shared by factories
+	// so that they don't collide with seed data
+	record := factory.Build()
+	require.NotEqual(t, int32(1), record.ID)
+	require.Equal(t, "name 1", record.Name)
+	require.Equal(t, &model.SampleTable{ID: record.ID + 1, Name: "Joe"}, factory.Build(func(e *model.SampleTable) {
+		e.Name = "Joe"
+	}))
+
+	records, err := factory.CreateMany(context.Background(), 2)
+	require.NoError(t, err)
+	id := record.ID + 2
+	require.Equal(t, []*model.SampleTable{
+		{ID: id, Name: "name 3"},
+		{ID: id + 1, Name: "name 4"},
+	}, records)
+	require.Equal(t, "INSERT INTO sample_table (id,name) VALUES ($1,$2),($3,$4)", db.sql)
+	require.Equal(t, []interface{}{id, "name 3", id + 1, "name 4"}, db.args)
+	require.Equal(t, "name 1", model.NewSampleTableFactory(sqlb.Factory{DB: db}).Build().Name)
+
+	records, err = factory.CreateMany(context.Background(), 0)
+	require.NoError(t, err)
+	require.Empty(t, records)
+}
@@ -0,0 +1,18 @@
+package schema
+
+import "github.com/bongnv/pggo/pkg/sqlb"
+
+// Accounts defines the schema of accounts.
+var Accounts = struct {
+	sqlb.BaseTable
+	CreatedAt string
+	ID        string
+	Name      string
+	Status    string
+}{
+	BaseTable: "accounts",
+	CreatedAt: "created_at",
+	ID:        "id",
+	Name:      "name",
+	Status:    "status",
+}
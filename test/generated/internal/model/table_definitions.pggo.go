@@ -0,0 +1,10 @@
+package model
+
+import "github.com/bongnv/pggo/pkg/sqlb"
+
+// TableDefinitions contains structures of tables generated into the package,
+// verify them against the live DB schema via sqlb.VerifySchema.
+var TableDefinitions = []*sqlb.TableDefinition{
+	SampleTableDefinition,
+	AccountsDefinition,
+}
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS events (
+  id BIGINT NOT NULL,
+  created_at DATE NOT NULL,
+  PRIMARY KEY (id, created_at)
+) PARTITION BY RANGE (created_at);
+
+CREATE TABLE IF NOT EXISTS events_2021 PARTITION OF events
+  FOR VALUES FROM ('2021-01-01') TO ('2022-01-01');
+
+CREATE TABLE IF NOT EXISTS events_2022 PARTITION OF events
+  FOR VALUES FROM ('2022-01-01') TO ('2023-01-01');
@@ -0,0 +1 @@
+CREATE TYPE status AS ENUM ('active', 'suspended');
@@ -0,0 +1,5 @@
+-- name: GetSampleTable :one
+SELECT id, name FROM sample_table WHERE id = @id;
+
+-- name: DeleteSampleTables :execrows
+DELETE FROM sample_table WHERE name = @name;